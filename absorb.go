@@ -0,0 +1,71 @@
+package baseError
+
+import (
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+const (
+	FieldHints        = "hints"
+	FieldDetails      = "details"
+	FieldForeignStack = "foreign_stack"
+)
+
+// Absorb wraps err like WrapStack but keeps the metadata that errors from
+// cockroachdb/errors (and anything else following its conventions) carry: the deepest
+// pkg/errors-compatible stack trace in the chain replaces a freshly captured one, and
+// ErrorHint/ErrorDetail texts are collected into the hints and details fields.
+func Absorb(code string, err error) *Error {
+	if isNil(err) {
+		return nil
+	}
+	e := &Error{Code: code, Msg: err.Error(), System: true, cause: err}
+
+	var hints, details []string
+	var trace errors.StackTrace
+	walk := []error{err}
+	for depth := 0; len(walk) > 0 && depth < maxCauseDepth; depth++ {
+		cur := walk[0]
+		walk = append(walk[1:], causes(cur)...)
+		if x, ok := cur.(interface{ ErrorHint() string }); ok && x.ErrorHint() != "" {
+			hints = append(hints, x.ErrorHint())
+		}
+		if x, ok := cur.(interface{ ErrorDetail() string }); ok && x.ErrorDetail() != "" {
+			details = append(details, x.ErrorDetail())
+		}
+		if x, ok := cur.(interface{ StackTrace() errors.StackTrace }); ok && len(x.StackTrace()) > 0 {
+			trace = x.StackTrace()
+		}
+	}
+
+	if len(trace) > 0 {
+		st := make(stack, len(trace))
+		for i, f := range trace {
+			st[i] = uintptr(f)
+		}
+		e.stack = &st
+	} else {
+		e.stack = Callers(3, stackDepth(e.registry(), e.cfg(), e.Code, e.System, AutoDepth))
+	}
+	if len(hints) > 0 {
+		e.WithField(FieldHints, hints)
+	}
+	if len(details) > 0 {
+		e.WithField(FieldDetails, details)
+	}
+	return created(e)
+}
+
+// ErrorHint exposes the hints field through the cockroachdb/errors ErrorHinter interface.
+func (b *Error) ErrorHint() string {
+	hints, _ := b.fields[FieldHints].([]string)
+	return strings.Join(hints, "\n")
+}
+
+// ErrorDetail exposes the details field through the cockroachdb/errors ErrorDetailer
+// interface.
+func (b *Error) ErrorDetail() string {
+	details, _ := b.fields[FieldDetails].([]string)
+	return strings.Join(details, "\n")
+}
@@ -0,0 +1,35 @@
+package baseError
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/pkg/errors"
+)
+
+type hinted struct {
+	error
+	hint, detail string
+}
+
+func (h hinted) ErrorHint() string   { return h.hint }
+func (h hinted) ErrorDetail() string { return h.detail }
+func (h hinted) Unwrap() error       { return h.error }
+
+func TestAbsorb(t *testing.T) {
+	root := errors.New("connection refused")
+	err := Absorb("DB_UNAVAILABLE", hinted{fmt.Errorf("dial: %w", root), "check DATABASE_URL", "host=db:5432"})
+
+	if err.Code != "DB_UNAVAILABLE" || !err.System || err.Msg != "dial: connection refused" {
+		t.Fatalf("got %v", err)
+	}
+	if err.ErrorHint() != "check DATABASE_URL" || err.ErrorDetail() != "host=db:5432" {
+		t.Errorf("hint=%q detail=%q", err.ErrorHint(), err.ErrorDetail())
+	}
+	if got, want := err.StackTrace()[0], root.(interface{ StackTrace() errors.StackTrace }).StackTrace()[0]; got != want {
+		t.Errorf("stack should be taken from the root error, got %v want %v", got, want)
+	}
+	if Absorb("X", nil) != nil {
+		t.Error("absorbing nil should return nil")
+	}
+}
@@ -0,0 +1,160 @@
+package baseError
+
+import "sync"
+
+const (
+	FieldUpstream  = "upstream"
+	FieldUpstreams = "upstreams"
+	FieldPartial   = "partial"
+)
+
+// Upstream is the outcome of one call made by an Aggregator's caller.
+type Upstream struct {
+	Name string
+	Err  error
+}
+
+// UpstreamError is the client-facing form of one failed upstream: its sanitized code and
+// message.
+type UpstreamError struct {
+	Upstream string `json:"upstream"`
+	Code     string `json:"code"`
+	Msg      string `json:"msg"`
+}
+
+// PrimaryPolicy picks the index of the failure an Aggregator reports as its primary error.
+type PrimaryPolicy func(failed []Upstream) int
+
+// PrimaryByPriority picks the most significant failure like Prioritize does.
+func PrimaryByPriority(failed []Upstream) int {
+	priority := cfg().Priority
+	if priority == nil {
+		priority = DefaultPriority
+	}
+	best, bestRank := 0, 0
+	for i, u := range failed {
+		if rank := priority(u.Err); i == 0 || rank > bestRank {
+			best, bestRank = i, rank
+		}
+	}
+	return best
+}
+
+// PrimaryByOrder picks the failure of the first upstream in names that failed, e.g. the
+// one a page can't render without, and falls back to PrimaryByPriority.
+func PrimaryByOrder(names ...string) PrimaryPolicy {
+	return func(failed []Upstream) int {
+		for _, name := range names {
+			for i, u := range failed {
+				if u.Name == name {
+					return i
+				}
+			}
+		}
+		return PrimaryByPriority(failed)
+	}
+}
+
+// Aggregator merges the failures of the upstream calls made for one backend-for-frontend
+// request into a single error. It is safe for concurrent use.
+type Aggregator struct {
+	policy PrimaryPolicy
+	mu     sync.Mutex
+	total  int
+	failed []Upstream
+}
+
+// NewAggregator returns an Aggregator choosing its primary error with policy, or
+// PrimaryByPriority if policy is nil.
+func NewAggregator(policy PrimaryPolicy) *Aggregator {
+	if policy == nil {
+		policy = PrimaryByPriority
+	}
+	return &Aggregator{policy: policy}
+}
+
+// Add records the outcome of the call to upstream; a nil err records a success.
+func (a *Aggregator) Add(upstream string, err error) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.total++
+	if !isNil(err) {
+		a.failed = append(a.failed, Upstream{Name: upstream, Err: err})
+	}
+}
+
+// Failed returns the failed upstreams in the order they were added.
+func (a *Aggregator) Failed() []Upstream {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	return append([]Upstream(nil), a.failed...)
+}
+
+// Err returns nil if no upstream failed. Otherwise it returns an error with the code,
+// message and kind of the primary failure, all failures as causes, the primary's name
+// under the upstream field, every failure as []UpstreamError under upstreams, and
+// partial set when some upstream succeeded.
+func (a *Aggregator) Err() *Error {
+	failed := a.Failed()
+	if len(failed) == 0 {
+		return nil
+	}
+	a.mu.Lock()
+	partial := a.total > len(failed)
+	a.mu.Unlock()
+
+	primary := failed[a.policy(failed)]
+	p := asError(primary.Err)
+	errs := make([]error, len(failed))
+	for i, u := range failed {
+		errs[i] = u.Err
+	}
+	var cause error = primary.Err
+	if len(errs) > 1 {
+		cause = &joined{errs: errs}
+	}
+	out := created(&Error{Code: p.Code, Msg: p.Msg, System: p.System, cause: cause, safeDetails: p.SafeDetails()})
+	out.WithField(FieldUpstream, primary.Name).WithField(FieldUpstreams, upstreamErrors(failed))
+	if partial {
+		out.WithField(FieldPartial, true)
+	}
+	return out
+}
+
+// AggregateEnvelope is the client-facing rendering of an Aggregator's error.
+type AggregateEnvelope struct {
+	Code      string          `json:"code"`
+	Msg       string          `json:"msg"`
+	Ref       string          `json:"ref,omitempty"`
+	Upstream  string          `json:"upstream,omitempty"`
+	Partial   bool            `json:"partial,omitempty"`
+	Upstreams []UpstreamError `json:"upstreams"`
+}
+
+// Envelope renders the aggregated error with every code and message sanitized, or
+// returns nil if no upstream failed. It also returns the error it rendered, which
+// carries the envelope's ref for System failures, to be logged in place of Err.
+func (a *Aggregator) Envelope() (*AggregateEnvelope, *Error) {
+	e := a.Err()
+	if e == nil {
+		return nil, nil
+	}
+	s, stamped := sanitize(e)
+	if stamped != nil {
+		e = stamped
+	}
+	out := &AggregateEnvelope{Code: s.Code, Msg: s.Msg, Upstreams: e.fields[FieldUpstreams].([]UpstreamError)}
+	out.Ref, _ = s.fields[FieldReference].(string)
+	out.Upstream, _ = e.fields[FieldUpstream].(string)
+	out.Partial, _ = e.fields[FieldPartial].(bool)
+	return out, e
+}
+
+func upstreamErrors(failed []Upstream) []UpstreamError {
+	out := make([]UpstreamError, len(failed))
+	for i, u := range failed {
+		s := Sanitize(u.Err)
+		out[i] = UpstreamError{Upstream: u.Name, Code: s.Code, Msg: s.Msg}
+	}
+	return out
+}
@@ -0,0 +1,51 @@
+package baseError
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+)
+
+func TestAggregator(t *testing.T) {
+	a := NewAggregator(nil)
+	if env, e := a.Envelope(); a.Err() != nil || env != nil || e != nil {
+		t.Fatal("empty aggregator failed")
+	}
+	a.Add("profile", nil)
+	a.Add("orders", New("AGG_ORDERS_FORBIDDEN", "no access to orders"))
+	a.Add("payments", System("AGG_PAYMENTS_DOWN", "payments: dial tcp refused"))
+
+	e := a.Err()
+	if e.Code != "AGG_PAYMENTS_DOWN" || !e.System || e.Fields()[FieldUpstream] != "payments" || e.Fields()[FieldPartial] != true {
+		t.Errorf("err = %v fields = %v", e, e.Fields())
+	}
+	if !errors.Is(e, New("AGG_ORDERS_FORBIDDEN", "")) || len(e.Causes()) != 2 {
+		t.Error("failures not kept as causes")
+	}
+
+	env, logged := a.Envelope()
+	ref, _ := logged.Fields()[FieldReference].(string)
+	if ref == "" || env.Ref != ref {
+		t.Errorf("envelope ref = %q, logged ref = %q", env.Ref, ref)
+	}
+	buf, _ := json.Marshal(env)
+	want := `{"code":"` + CurrentConfig().SanitizedCode + `","msg":"` + CurrentConfig().SanitizedMsg + `","ref":"` + ref + `","upstream":"payments","partial":true,"upstreams":[{"upstream":"orders","code":"AGG_ORDERS_FORBIDDEN","msg":"no access to orders"},{"upstream":"payments","code":"` + CurrentConfig().SanitizedCode + `","msg":"` + CurrentConfig().SanitizedMsg + `"}]}`
+	if string(buf) != want {
+		t.Errorf("envelope = %s", buf)
+	}
+}
+
+func TestPrimaryByOrder(t *testing.T) {
+	a := NewAggregator(PrimaryByOrder("orders"))
+	a.Add("payments", System("AGG_PAYMENTS_DOWN", ""))
+	a.Add("orders", New("AGG_ORDERS_FORBIDDEN", "no access"))
+	if e := a.Err(); e.Code != "AGG_ORDERS_FORBIDDEN" || e.System || e.Fields()[FieldPartial] != nil {
+		t.Errorf("err = %v fields = %v", e, e.Fields())
+	}
+
+	a = NewAggregator(PrimaryByOrder("search"))
+	a.Add("legacy", errors.New("raw"))
+	if e := a.Err(); e.Code != DefaultWrapCode() || e.Cause().Error() != "raw" {
+		t.Errorf("err = %v", e)
+	}
+}
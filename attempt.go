@@ -0,0 +1,101 @@
+package baseError
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	FieldAttempt      = "attempt"
+	FieldRetryHistory = "retry_history"
+)
+
+// RetryRecord is one failed attempt: when it failed and with which code.
+type RetryRecord struct {
+	At   time.Time
+	Code string
+}
+
+// RetryHistory lists the failed attempts of an operation, oldest first. It marshals to
+// the compact text form "unixmillis:CODE;unixmillis:CODE" so it fits in message headers.
+type RetryHistory []RetryRecord
+
+// Add returns h with err recorded as failing now. h itself is not modified.
+func (h RetryHistory) Add(err error) RetryHistory {
+	out := make(RetryHistory, len(h), len(h)+1)
+	copy(out, h)
+	return append(out, RetryRecord{At: Now(), Code: CodeOf(err)})
+}
+
+func (h RetryHistory) String() string {
+	parts := make([]string, len(h))
+	for i, r := range h {
+		parts[i] = strconv.FormatInt(r.At.UnixMilli(), 10) + ":" + r.Code
+	}
+	return strings.Join(parts, ";")
+}
+
+func (h RetryHistory) MarshalText() ([]byte, error) {
+	return []byte(h.String()), nil
+}
+
+func (h *RetryHistory) UnmarshalText(text []byte) error {
+	parsed, err := ParseRetryHistory(string(text))
+	if err != nil {
+		return err
+	}
+	*h = parsed
+	return nil
+}
+
+func ParseRetryHistory(s string) (RetryHistory, error) {
+	if s == "" {
+		return nil, nil
+	}
+	var h RetryHistory
+	for _, part := range strings.Split(s, ";") {
+		ms, code, ok := strings.Cut(part, ":")
+		n, err := strconv.ParseInt(ms, 10, 64)
+		if !ok || err != nil {
+			return nil, fmt.Errorf("baseError: invalid retry history entry %q", part)
+		}
+		h = append(h, RetryRecord{At: time.UnixMilli(n), Code: code})
+	}
+	return h, nil
+}
+
+func (b *Error) WithAttempt(n int) *Error {
+	return b.WithField(FieldAttempt, n)
+}
+
+// Attempt returns the attempt set by WithAttempt. It also reads the float64 a JSON
+// round trip turns the field into.
+func (b *Error) Attempt() int {
+	if b == nil {
+		return 0
+	}
+	switch n := b.fields[FieldAttempt].(type) {
+	case int:
+		return n
+	case int64:
+		return int(n)
+	case float64:
+		return int(n)
+	}
+	return 0
+}
+
+// WithRetryHistory attaches the failures that preceded this one.
+func (b *Error) WithRetryHistory(h RetryHistory) *Error {
+	return b.WithField(FieldRetryHistory, h)
+}
+
+func (b *Error) RetryHistory() RetryHistory {
+	if b == nil {
+		return nil
+	}
+	h, _ := b.fields[FieldRetryHistory].(RetryHistory)
+	return append(RetryHistory(nil), h...)
+}
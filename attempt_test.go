@@ -0,0 +1,33 @@
+package baseError
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+)
+
+func TestRetryHistory(t *testing.T) {
+	var h RetryHistory
+	h = h.Add(System(CodeNetTimeout, ""))
+	h2 := h.Add(System(CodeNetReset, ""))
+	if len(h) != 1 || len(h2) != 2 {
+		t.Fatalf("Add is not append-only: %v %v", h, h2)
+	}
+
+	parsed, err := ParseRetryHistory(h2.String())
+	if err != nil || len(parsed) != 2 || parsed[1].Code != CodeNetReset || !parsed[0].At.Equal(h2[0].At.Truncate(time.Millisecond)) {
+		t.Errorf("round trip = %v, %v", parsed, err)
+	}
+	if _, err := ParseRetryHistory("x:CODE"); err == nil {
+		t.Error("parsed an invalid entry")
+	}
+
+	e := System("FETCH_FAILED", "gave up").WithAttempt(3).WithRetryHistory(h2)
+	if e.Attempt() != 3 || len(e.RetryHistory()) != 2 {
+		t.Errorf("got %v %v", e.Attempt(), e.RetryHistory())
+	}
+	buf, _ := json.Marshal(struct{ H RetryHistory }{h2})
+	if want := `{"H":"` + h2.String() + `"}`; string(buf) != want {
+		t.Errorf("json = %s", buf)
+	}
+}
@@ -5,7 +5,6 @@ import (
 	"github.com/pkg/errors"
 	"io"
 	"path/filepath"
-	"reflect"
 	"runtime"
 	"strings"
 )
@@ -29,15 +28,88 @@ func getSourceDir(file string) string {
 }
 
 func IsSystemError(err error) bool {
-	return reflect.TypeOf(err).String() == "*baseError.Error" && err.(*Error).System
+	e, ok := err.(*Error)
+	return ok && e != nil && e.System
+}
+
+// isNil reports whether err is nil or a nil *Error, which every helper treats alike.
+func isNil(err error) bool {
+	return err == nil || err == (*Error)(nil)
+}
+
+// errorOf returns the first *Error in err's chain, or nil. Unlike errors.As it doesn't
+// use reflection, and a nil *Error counts as absent.
+func errorOf(err error) *Error {
+	for err != nil {
+		switch x := err.(type) {
+		case *Error:
+			return x
+		case interface{ Unwrap() error }:
+			err = x.Unwrap()
+		case interface{ Unwrap() []error }:
+			for _, c := range x.Unwrap() {
+				if e := errorOf(c); e != nil {
+					return e
+				}
+			}
+			return nil
+		default:
+			return nil
+		}
+	}
+	return nil
+}
+
+// CodeLegacy marks errors produced by the pkg/errors compatibility shims. Their Error()
+// omits the code prefix and CodeOf looks through them to the first real code.
+const CodeLegacy = "LEGACY"
+
+// SetDefaultWrapCode sets the code CodeOf reports for errors that carry no *Error. It
+// defaults to "UNKNOWN".
+func SetDefaultWrapCode(code string) {
+	updateConfig(func(c *Config) {
+		c.DefaultWrapCode = code
+	})
+}
+
+func DefaultWrapCode() string {
+	return cfg().DefaultWrapCode
+}
+
+// CodeOf returns the code of the first *Error in err's chain, DefaultWrapCode() if there
+// is none, or "" if err is nil.
+func CodeOf(err error) string {
+	return CodeOfOr(err, DefaultWrapCode())
+}
+
+// CodeOfOr is CodeOf with a boundary-specific fallback code for foreign errors.
+func CodeOfOr(err error, fallback string) string {
+	if isNil(err) {
+		return ""
+	}
+	for e := errorOf(err); e != nil; e = errorOf(e.cause) {
+		if e.Code != CodeLegacy {
+			return e.Code
+		}
+	}
+	return fallback
 }
 
 type Error struct {
-	Code   string `json:"code"`
-	Msg    string `json:"msg"`
-	System bool   `json:"-"`
-	Chain  string `json:"-"`
-	cause  error  `json:"-"`
+	Code         string `json:"code"`
+	Msg          string `json:"msg"`
+	System       bool   `json:"-"`
+	Chain        string `json:"-"`
+	cause        error  `json:"-"`
+	fields       map[string]interface{}
+	safeDetails  []string
+	debugDetails []string
+	sanitized    bool
+	retryable    *bool
+	severity     Severity
+	origin       Origin
+	scope        *Scope
+	args         []interface{}
 	*stack
 }
 
@@ -51,62 +123,130 @@ func (b *Error) WithChain(chain ...string) *Error {
 	return b
 }
 
+func (b *Error) WithField(key string, value interface{}) *Error {
+	if b.fields == nil {
+		b.fields = make(map[string]interface{})
+	}
+	b.fields[key] = value
+	return b
+}
+
+func (b *Error) WithFields(fields map[string]interface{}) *Error {
+	for k, v := range fields {
+		b.WithField(k, v)
+	}
+	return b
+}
+
+func (b *Error) Fields() map[string]interface{} {
+	if b == nil {
+		return nil
+	}
+	fields := make(map[string]interface{}, len(b.fields))
+	for k, v := range b.fields {
+		fields[k] = fieldValue(v)
+	}
+	return fields
+}
+
+// Copy returns a copy of b with its own fields and details, so that annotating the copy
+// leaves b, which may be a shared sentinel, untouched. Field values, the cause and the
+// stack are shared.
+func (b *Error) Copy() *Error {
+	if b == nil {
+		return nil
+	}
+	out := *b
+	out.fields = nil
+	for k, v := range b.fields {
+		out.WithField(k, v)
+	}
+	out.safeDetails = append([]string(nil), b.safeDetails...)
+	out.debugDetails = append([]string(nil), b.debugDetails...)
+	return &out
+}
+
 func (b *Error) Error() string {
+	if b == nil {
+		return "<nil>"
+	}
+	if b.Code == CodeLegacy {
+		return b.Msg
+	}
 	return fmt.Sprintf("[%s] %s", b.Code, b.Msg)
 }
 
+// Format prints Error() for %s, %v and %q honoring width, precision and flags, the full
+// form described on WriteTo for %+v, and the Fingerprint for %x and %X. Other verbs are
+// reported the way fmt reports bad verbs.
 func (b *Error) Format(s fmt.State, verb rune) {
 	switch verb {
 	case 'v':
 		if s.Flag('+') {
-			io.WriteString(s, b.Error())
-			if b.stack != nil {
-				b.stack.Format(s, verb)
-			}
-			if b.cause != nil {
-				fmt.Fprintf(s, "\n---cause---\n%+v", b.cause)
-			}
+			b.WriteTo(s)
 			return
 		}
 		fallthrough
-	case 's':
-		io.WriteString(s, b.Error())
-	case 'q':
-		fmt.Fprintf(s, "%q", b.Error())
+	case 's', 'q':
+		fmt.Fprintf(s, fmt.FormatString(s, verb), b.Error())
+	case 'x':
+		fmt.Fprintf(s, fmt.FormatString(s, 's'), Fingerprint(b))
+	case 'X':
+		fmt.Fprintf(s, fmt.FormatString(s, 's'), strings.ToUpper(Fingerprint(b)))
+	default:
+		fmt.Fprintf(s, "%%!%c(*baseError.Error=%s)", verb, b.Error())
 	}
 }
 
 func (b *Error) Stack() *stack {
+	if b == nil {
+		return nil
+	}
 	return b.stack
 }
 
 func (b *Error) Cause() error {
+	if b == nil {
+		return nil
+	}
+	return b.cause
+}
+
+func (b *Error) Unwrap() error {
+	if b == nil {
+		return nil
+	}
 	return b.cause
 }
 
+// WithCause sets the cause returned by Cause and Unwrap, for errors built as literals
+// rather than by the constructors, such as test fakes.
+func (b *Error) WithCause(err error) *Error {
+	b.cause = err
+	return b
+}
+
 func New(code string, msg string) *Error {
-	return &Error{Code: code, Msg: msg}
+	return created(&Error{Code: code, Msg: msg})
 }
 
 func NewStack(code string, msg string, depth int) *Error {
-	if depth == 0 {
-		depth = 1
-	}
-	return &Error{Code: code, Msg: msg, stack: Callers(3, depth)}
+	depth = stackDepth(DefaultRegistry, cfg(), code, false, depth)
+	return created(&Error{Code: code, Msg: msg, stack: Callers(3, depth)})
 }
 
 func System(code string, msg string) *Error {
-	return &Error{Code: code, Msg: msg, System: true}
+	return created(&Error{Code: code, Msg: msg, System: true})
 }
 
 func SystemStack(code string, msg string, depth int) *Error {
-	if depth == 0 {
-		depth = 1
-	}
-	return &Error{Code: code, Msg: msg, System: true, stack: Callers(3, depth)}
+	depth = stackDepth(DefaultRegistry, cfg(), code, true, depth)
+	return created(&Error{Code: code, Msg: msg, System: true, stack: Callers(3, depth)})
 }
 
-func factoryFormat(arg ...string) (string, func(message ...interface{}) string) {
+// factoryFormat returns the code and the formatter of a factory's arguments. Besides the
+// message, the formatter returns the arguments to record for Localize.
+func factoryFormat(arg ...string) (string, func(message ...interface{}) (string, []interface{})) {
 	if len(arg) == 0 {
 		panic("ErrorFactory至少包含一个参数code")
 	}
@@ -126,11 +266,15 @@ func factoryFormat(arg ...string) (string, func(message ...interface{}) string)
 		msg = arg[1]
 	}
 
+	if parts, names := parseNamed(msg); names != nil {
+		return code, namedFormatter(parts, names)
+	}
 	if strings.Contains(msg, "{}") {
 		msg = strings.ReplaceAll(msg, "{}", "%v")
 	}
 	c := strings.Count(msg, "%v")
-	return code, func(message ...interface{}) string {
+	return code, func(message ...interface{}) (string, []interface{}) {
+		args := message
 		if len(message) < c {
 			for {
 				if len(message) == c {
@@ -139,63 +283,75 @@ func factoryFormat(arg ...string) (string, func(message ...interface{}) string)
 				message = append(message, "")
 			}
 		}
-		return fmt.Sprintf(msg, message...)
+		return fmt.Sprintf(msg, message...), args
 	}
 }
 
 func Factory(arg ...string) func(...interface{}) *Error {
 	code, formatter := factoryFormat(arg...)
+	registerFactory(DefaultRegistry, cfg(), code, arg)
 	return func(message ...interface{}) *Error {
-		fmtMsg := formatter(message...)
-		return &Error{Code: code, Msg: fmtMsg}
+		fmtMsg, args := formatter(message...)
+		return created(&Error{Code: code, Msg: fmtMsg, args: args})
 	}
 }
 
 func FactoryStack(depth int, arg ...string) func(...interface{}) *Error {
-	if depth == 0 {
-		depth = 1
-	}
 	code, formatter := factoryFormat(arg...)
+	registerFactory(DefaultRegistry, cfg(), code, arg)
 	return func(message ...interface{}) *Error {
-		fmtMsg := formatter(message...)
-		return &Error{Code: code, Msg: fmtMsg, stack: Callers(3, depth)}
+		fmtMsg, args := formatter(message...)
+		return created(&Error{Code: code, Msg: fmtMsg, args: args, stack: Callers(3, stackDepth(DefaultRegistry, cfg(), code, false, depth))})
 	}
 }
 
 func SystemFactory(arg ...string) func(...interface{}) *Error {
 	code, formatter := factoryFormat(arg...)
+	registerFactory(DefaultRegistry, cfg(), code, arg)
 	return func(message ...interface{}) *Error {
-		fmtMsg := formatter(message...)
-		return &Error{Code: code, Msg: fmtMsg, System: true}
+		fmtMsg, args := formatter(message...)
+		return created(&Error{Code: code, Msg: fmtMsg, args: args, System: true})
 	}
 }
 
 func SystemFactoryStack(depth int, arg ...string) func(...interface{}) *Error {
-	if depth == 0 {
-		depth = 1
-	}
 	code, formatter := factoryFormat(arg...)
+	registerFactory(DefaultRegistry, cfg(), code, arg)
 	return func(message ...interface{}) *Error {
-		fmtMsg := formatter(message...)
-		return &Error{Code: code, Msg: fmtMsg, System: true, stack: Callers(3, depth)}
+		fmtMsg, args := formatter(message...)
+		return created(&Error{Code: code, Msg: fmtMsg, args: args, System: true, stack: Callers(3, stackDepth(DefaultRegistry, cfg(), code, true, depth))})
 	}
 }
 
 func Wrap(code string, err error) *Error {
-	if err == nil {
+	if isNil(err) {
 		return nil
 	}
-	return &Error{Code: code, Msg: err.Error(), System: true, cause: err}
+	return created(&Error{Code: code, Msg: err.Error(), System: true, cause: err})
 }
 
 func WrapStack(code string, err error, depth int) *Error {
-	if err == nil {
+	if isNil(err) {
 		return nil
 	}
-	if depth == 0 {
-		depth = 1
+	depth = stackDepth(DefaultRegistry, cfg(), code, true, depth)
+	return created(&Error{Code: code, Msg: err.Error(), System: true, cause: err, stack: Callers(3, depth)})
+}
+
+// Errorf formats the message like fmt.Errorf. An error passed for %w becomes the cause
+// and makes the result a System error, as with Wrap; several %w verbs keep all of them
+// reachable through errors.Is and errors.As.
+func Errorf(code string, format string, args ...interface{}) *Error {
+	formatted := fmt.Errorf(format, args...)
+	e := &Error{Code: code, Msg: formatted.Error()}
+	switch w := formatted.(type) {
+	case interface{ Unwrap() error }:
+		e.cause = w.Unwrap()
+	case interface{ Unwrap() []error }:
+		e.cause = formatted
 	}
-	return &Error{Code: code, Msg: err.Error(), System: true, cause: err, stack: Callers(3, depth)}
+	e.System = e.cause != nil
+	return created(e)
 }
 
 func WrapFactory(code string) func(err error) *Error {
@@ -205,9 +361,6 @@ func WrapFactory(code string) func(err error) *Error {
 }
 
 func WrapFactoryStack(depth int, code string) func(err error) *Error {
-	if depth == 0 {
-		depth = 1
-	}
 	return func(err error) *Error {
 		return WrapStack(code, err, depth)
 	}
@@ -220,20 +373,38 @@ func (s *stack) Format(st fmt.State, verb rune) {
 	case 'v':
 		switch {
 		case st.Flag('+'):
-			//for _, pc := range *s {
-			//	frame, _ := runtime.CallersFrames([]uintptr{pc}).Next()
-			//	if frame.PC != 0 {
-			//		fmt.Fprintf(st, "\n%s:%d", frame.File, frame.Line)
-			//	}
-			//}
-			for _, pc := range *s {
-				f := errors.Frame(pc)
-				fmt.Fprintf(st, "\n%+v", f)
-			}
+			s.writeFrames(st)
 		}
 	}
 }
+
+func (s *stack) writeFrames(w io.Writer) {
+	for _, f := range s.Frames() {
+		fmt.Fprintf(w, "\n%s\n\t%s:%d", f.Function, f.File, f.Line)
+	}
+}
+
+// Frames resolves the stack with runtime.CallersFrames, so frames of inlined calls are
+// expanded instead of being folded into their caller.
+func (s *stack) Frames() []runtime.Frame {
+	if s == nil || len(*s) == 0 {
+		return nil
+	}
+	var out []runtime.Frame
+	frames := runtime.CallersFrames(*s)
+	for {
+		f, more := frames.Next()
+		out = append(out, f)
+		if !more {
+			break
+		}
+	}
+	return out
+}
 func (s *stack) StackTrace() errors.StackTrace {
+	if s == nil {
+		return nil
+	}
 	f := make([]errors.Frame, len(*s))
 	for i := 0; i < len(f); i++ {
 		f[i] = errors.Frame((*s)[i])
@@ -241,11 +412,23 @@ func (s *stack) StackTrace() errors.StackTrace {
 	return f
 }
 
+func inSourceDirs(file string) bool {
+	for _, dir := range cfg().SourceDirs {
+		if strings.HasPrefix(file, dir) {
+			return true
+		}
+	}
+	return false
+}
+
 func Callers(skip int, depth int) *stack {
+	if cfg().DisableStacks {
+		return nil
+	}
 	var s = skip
 	for i := skip; i < 15; i++ {
 		_, file, _, ok := runtime.Caller(i)
-		if ok && (!strings.HasPrefix(file, sourceDir) || strings.HasSuffix(file, "_test.go")) {
+		if ok && (!inSourceDirs(file) || strings.HasSuffix(file, "_test.go")) {
 			s = i + 1
 			break
 		}
@@ -257,7 +440,7 @@ func Callers(skip int, depth int) *stack {
 }
 
 func WithStack(err error, depth int) error {
-	if err == nil {
+	if isNil(err) {
 		return nil
 	}
 	return &withStack{
@@ -273,6 +456,8 @@ type withStack struct {
 
 func (w *withStack) Cause() error { return w.error }
 
+func (w *withStack) Unwrap() error { return w.error }
+
 func (w *withStack) Format(s fmt.State, verb rune) {
 	switch verb {
 	case 'v':
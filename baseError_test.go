@@ -1,8 +1,79 @@
 package baseError
 
-import "testing"
+import (
+	"errors"
+	"fmt"
+	"io"
+	"runtime"
+	"strings"
+	"testing"
+)
 
 func TestNewBaseError(t *testing.T) {
 	err := New("23", "33")
 	t.Log(err)
 }
+
+func captureInlined() *stack {
+	var pcs [8]uintptr
+	n := runtime.Callers(1, pcs[:])
+	var st stack = pcs[:n]
+	return &st
+}
+
+func TestStackExpandsInlinedFrames(t *testing.T) {
+	st := captureInlined()
+	out := fmt.Sprintf("%+v", st)
+	if !strings.Contains(out, "captureInlined") || !strings.Contains(out, "TestStackExpandsInlinedFrames") {
+		t.Errorf("stack = %s", out)
+	}
+}
+
+func TestCodeOf(t *testing.T) {
+	if code := CodeOf(fmt.Errorf("ctx: %w", New("A", ""))); code != "A" {
+		t.Errorf("code = %s", code)
+	}
+	if code := CodeOf(io.EOF); code != "UNKNOWN" {
+		t.Errorf("code = %s", code)
+	}
+	if code := CodeOfOr(io.EOF, "STORAGE_IO"); code != "STORAGE_IO" {
+		t.Errorf("code = %s", code)
+	}
+	if code := CodeOf(nil); code != "" {
+		t.Errorf("code = %s", code)
+	}
+
+	SetDefaultWrapCode("INTERNAL")
+	defer SetDefaultWrapCode("UNKNOWN")
+	if code := CodeOf(io.EOF); code != "INTERNAL" {
+		t.Errorf("code = %s", code)
+	}
+}
+
+func TestUnwrap(t *testing.T) {
+	err := Wrap("OUTER", WrapStack("INNER", io.EOF, 4))
+	if !errors.Is(err, io.EOF) {
+		t.Error("errors.Is does not reach the root cause")
+	}
+	var inner *Error
+	if !errors.As(err.Unwrap(), &inner) || inner.Code != "INNER" {
+		t.Errorf("unwrap = %v", err.Unwrap())
+	}
+	if ws := WithStack(io.EOF, 4); errors.Unwrap(ws) != io.EOF {
+		t.Errorf("withStack unwrap = %v", errors.Unwrap(ws))
+	}
+}
+
+func TestErrorf(t *testing.T) {
+	err := Errorf("CONFIG_LOAD", "read %s: %w", "app.yaml", io.ErrUnexpectedEOF)
+	if err.Msg != "read app.yaml: unexpected EOF" || !err.System || err.Unwrap() != io.ErrUnexpectedEOF {
+		t.Errorf("got %v, cause %v", err, err.Unwrap())
+	}
+	if plain := Errorf("BAD_INPUT", "field %q is required", "email"); plain.System || plain.Unwrap() != nil {
+		t.Errorf("got %v", plain)
+	}
+	both := Errorf("MULTI", "%w and %w", io.EOF, New("INNER", ""))
+	if !errors.Is(both, io.EOF) || !errors.Is(both, CodeError("INNER")) {
+		t.Errorf("causes unreachable: %v", both)
+	}
+}
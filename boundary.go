@@ -0,0 +1,76 @@
+package baseError
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// Boundary declares the codes a package may return to its callers. Codes ending in ".*"
+// allow a whole domain, e.g. "billing.*" for the codes of a "billing" sub-registry.
+// Boundaries are enforced at run time, by tests or middleware calling Check; the codes a
+// function returns are generally not known statically, so no analyzer checks them.
+type Boundary struct {
+	name     string
+	codes    map[string]bool
+	prefixes []string
+}
+
+func NewBoundary(name string, codes ...string) *Boundary {
+	b := &Boundary{name: name, codes: make(map[string]bool)}
+	b.Allow(codes...)
+	return b
+}
+
+func (b *Boundary) Allow(codes ...string) *Boundary {
+	for _, code := range codes {
+		if strings.HasSuffix(code, "*") {
+			b.prefixes = append(b.prefixes, strings.TrimSuffix(code, "*"))
+		} else {
+			b.codes[code] = true
+		}
+	}
+	return b
+}
+
+func (b *Boundary) Allows(code string) bool {
+	if b.codes[code] {
+		return true
+	}
+	for _, prefix := range b.prefixes {
+		if strings.HasPrefix(code, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// Codes returns the allowed codes and domains, sorted.
+func (b *Boundary) Codes() []string {
+	out := make([]string, 0, len(b.codes)+len(b.prefixes))
+	for code := range b.codes {
+		out = append(out, code)
+	}
+	for _, prefix := range b.prefixes {
+		out = append(out, prefix+"*")
+	}
+	sort.Strings(out)
+	return out
+}
+
+// Check returns an error describing the leak if err, as returned by the package, is a
+// foreign error or an *Error whose outermost code the boundary doesn't allow. It returns
+// nil for nil errors and allowed codes.
+func (b *Boundary) Check(err error) error {
+	if isNil(err) {
+		return nil
+	}
+	e := errorOf(err)
+	if e == nil {
+		return fmt.Errorf("baseError: boundary %s: foreign error %T leaked: %v", b.name, err, err)
+	}
+	if !b.Allows(e.Code) {
+		return fmt.Errorf("baseError: boundary %s: code %s leaked, allowed: %s", b.name, e.Code, strings.Join(b.Codes(), ", "))
+	}
+	return nil
+}
@@ -0,0 +1,32 @@
+package baseError
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func TestBoundary(t *testing.T) {
+	b := NewBoundary("orders", "ORDER_NOT_FOUND", "billing.*")
+	for _, err := range []error{
+		nil,
+		New("ORDER_NOT_FOUND", ""),
+		fmt.Errorf("load: %w", New("billing.CARD_DECLINED", "")),
+	} {
+		if leak := b.Check(err); leak != nil {
+			t.Errorf("Check(%v) = %v", err, leak)
+		}
+	}
+
+	leak := b.Check(Wrap("DB_TIMEOUT", errors.New("timeout")))
+	if leak == nil || !strings.Contains(leak.Error(), "code DB_TIMEOUT leaked, allowed: ORDER_NOT_FOUND, billing.*") {
+		t.Errorf("leak = %v", leak)
+	}
+	if leak := b.Check(errors.New("eof")); leak == nil || !strings.Contains(leak.Error(), "foreign error *errors.errorString") {
+		t.Errorf("leak = %v", leak)
+	}
+	if !b.Allow("ORDER_CANCELLED").Allows("ORDER_CANCELLED") {
+		t.Error("Allow")
+	}
+}
@@ -0,0 +1,78 @@
+package baseError
+
+import (
+	"sync"
+	"time"
+)
+
+const budgetBuckets = 60
+
+// Budget tracks an error budget: the share of operations allowed to fail within a sliding
+// window for the success objective (e.g. 0.999) to hold. Only System and foreign errors
+// burn budget; business errors are successful outcomes from the service's point of view.
+type Budget struct {
+	objective float64
+	width     time.Duration
+	mu        sync.Mutex
+	buckets   [budgetBuckets]struct {
+		start         time.Time
+		total, failed int
+	}
+}
+
+// NewBudget returns a Budget for objective over window. The window is split into 60
+// buckets of at least a nanosecond each, so very short windows are rounded up to 60ns.
+func NewBudget(objective float64, window time.Duration) *Budget {
+	width := window / budgetBuckets
+	if width < 1 {
+		width = 1
+	}
+	return &Budget{objective: objective, width: width}
+}
+
+func (b *Budget) Record(err error) {
+	e := errorOf(err)
+	failed := !isNil(err) && (e == nil || e.System)
+
+	start := Now().Truncate(b.width)
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	bucket := &b.buckets[(start.UnixNano()/int64(b.width))%budgetBuckets]
+	if !bucket.start.Equal(start) {
+		bucket.start, bucket.total, bucket.failed = start, 0, 0
+	}
+	bucket.total++
+	if failed {
+		bucket.failed++
+	}
+}
+
+// Remaining returns the unspent fraction of the budget: 1 when nothing failed, 0 or less
+// once the objective is violated within the window.
+func (b *Budget) Remaining() float64 {
+	cutoff := Now().Add(-b.width * budgetBuckets)
+	var total, failed int
+	b.mu.Lock()
+	for _, bucket := range b.buckets {
+		if bucket.start.After(cutoff) {
+			total += bucket.total
+			failed += bucket.failed
+		}
+	}
+	b.mu.Unlock()
+	if total == 0 {
+		return 1
+	}
+	allowed := (1 - b.objective) * float64(total)
+	if allowed == 0 {
+		if failed > 0 {
+			return 0
+		}
+		return 1
+	}
+	return 1 - float64(failed)/allowed
+}
+
+func (b *Budget) Exhausted() bool {
+	return b.Remaining() <= 0
+}
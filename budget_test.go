@@ -0,0 +1,37 @@
+package baseError
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestBudget(t *testing.T) {
+	b := NewBudget(0.75, time.Minute)
+	if b.Remaining() != 1 {
+		t.Errorf("remaining = %v", b.Remaining())
+	}
+	for i := 0; i < 18; i++ {
+		b.Record(nil)
+	}
+	b.Record(New("NOT_FOUND", ""))
+	b.Record(errors.New("timeout"))
+	if r := b.Remaining(); r != 0.8 {
+		t.Errorf("remaining = %v", r)
+	}
+	for i := 0; i < 8; i++ {
+		b.Record(System("DB", ""))
+	}
+	if !b.Exhausted() {
+		t.Errorf("remaining = %v", b.Remaining())
+	}
+}
+
+func TestBudgetShortWindow(t *testing.T) {
+	b := NewBudget(0.5, 10*time.Nanosecond)
+	b.Record(nil)
+	b.Record(System("TEST_DOWN", ""))
+	if got := b.Remaining(); got > 1 {
+		t.Errorf("Remaining = %v", got)
+	}
+}
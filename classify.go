@@ -0,0 +1,143 @@
+package baseError
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"io"
+	"io/fs"
+	"net"
+	"syscall"
+)
+
+const (
+	CodeNetTimeout     = "NET_TIMEOUT"
+	CodeNetDNS         = "NET_DNS"
+	CodeNetRefused     = "NET_CONN_REFUSED"
+	CodeNetReset       = "NET_CONN_RESET"
+	CodeNetTLS         = "NET_TLS"
+	CodeNetUnreachable = "NET_UNREACHABLE"
+
+	CodeFSNotFound    = "FS_NOT_FOUND"
+	CodeFSPermission  = "FS_PERMISSION"
+	CodeFSExists      = "FS_EXISTS"
+	CodeFSDiskFull    = "FS_DISK_FULL"
+	CodeFSPathTooLong = "FS_PATH_TOO_LONG"
+	CodeFSReadOnly    = "FS_READ_ONLY"
+	CodeFSTooMany     = "FS_TOO_MANY_OPEN_FILES"
+
+	FieldErrno = "errno"
+	FieldPath  = "path"
+)
+
+func init() {
+	for _, code := range []string{CodeNetTimeout, CodeNetRefused, CodeNetReset, CodeNetUnreachable} {
+		RegisterRetryable(code, true)
+	}
+}
+
+// NetCode classifies a network error into one of the CodeNet codes, or returns "" if err
+// is not a network error. Timeouts, refused and reset connections and unreachable
+// networks are registered as retryable.
+func NetCode(err error) string {
+	if isNil(err) {
+		return ""
+	}
+	var dns *net.DNSError
+	if errors.As(err, &dns) && !dns.IsTimeout {
+		return CodeNetDNS
+	}
+	var unknownAuthority x509.UnknownAuthorityError
+	var hostname x509.HostnameError
+	var invalid x509.CertificateInvalidError
+	var header tls.RecordHeaderError
+	if errors.As(err, &unknownAuthority) || errors.As(err, &hostname) || errors.As(err, &invalid) || errors.As(err, &header) {
+		return CodeNetTLS
+	}
+	switch {
+	case errors.Is(err, syscall.ECONNREFUSED):
+		return CodeNetRefused
+	case errors.Is(err, syscall.ECONNRESET), errors.Is(err, syscall.EPIPE), errors.Is(err, io.ErrUnexpectedEOF):
+		return CodeNetReset
+	case errors.Is(err, syscall.ENETUNREACH), errors.Is(err, syscall.EHOSTUNREACH):
+		return CodeNetUnreachable
+	}
+	var ne net.Error
+	if errors.As(err, &ne) && ne.Timeout() {
+		return CodeNetTimeout
+	}
+	var op *net.OpError
+	if errors.As(err, &op) {
+		return CodeNetUnreachable
+	}
+	return ""
+}
+
+// WrapNet wraps err with its NetCode as a System error, or returns nil if err is not a
+// network error.
+func WrapNet(err error) *Error {
+	code := NetCode(err)
+	if code == "" {
+		return nil
+	}
+	return Wrap(code, err)
+}
+
+var fsMessages = map[string]string{
+	CodeFSNotFound:    "file not found",
+	CodeFSPermission:  "permission denied",
+	CodeFSExists:      "file already exists",
+	CodeFSDiskFull:    "no space left on device",
+	CodeFSPathTooLong: "file name too long",
+	CodeFSReadOnly:    "read-only file system",
+	CodeFSTooMany:     "too many open files",
+}
+
+// FSCode classifies an os or io/fs error into one of the CodeFS codes, or returns "" if
+// err is not a file system error.
+func FSCode(err error) string {
+	switch {
+	case isNil(err):
+		return ""
+	case errors.Is(err, syscall.ENOSPC), errors.Is(err, syscall.EDQUOT):
+		return CodeFSDiskFull
+	case errors.Is(err, syscall.ENAMETOOLONG):
+		return CodeFSPathTooLong
+	case errors.Is(err, syscall.EROFS):
+		return CodeFSReadOnly
+	case errors.Is(err, syscall.EMFILE), errors.Is(err, syscall.ENFILE):
+		return CodeFSTooMany
+	case errors.Is(err, fs.ErrNotExist):
+		return CodeFSNotFound
+	case errors.Is(err, fs.ErrPermission):
+		return CodeFSPermission
+	case errors.Is(err, fs.ErrExist):
+		return CodeFSExists
+	}
+	return ""
+}
+
+// WrapFS wraps a file system error with its FSCode and a fixed message, so the raw OS
+// text only survives as the cause. The path and errno are kept as fields. Not found,
+// permission, exists and path too long are business errors; the rest are System errors.
+// It returns nil if err is not a file system error.
+func WrapFS(err error) *Error {
+	code := FSCode(err)
+	if code == "" {
+		return nil
+	}
+	e := &Error{Code: code, Msg: fsMessages[code], cause: err}
+	switch code {
+	case CodeFSDiskFull, CodeFSReadOnly, CodeFSTooMany:
+		e.System = true
+	}
+	var pe *fs.PathError
+	if errors.As(err, &pe) {
+		e.WithField(FieldPath, pe.Path)
+	}
+	var errno syscall.Errno
+	if errors.As(err, &errno) {
+		e.WithField(FieldErrno, int(errno))
+	}
+	return created(e)
+}
@@ -0,0 +1,58 @@
+package baseError
+
+import (
+	"errors"
+	"net"
+	"os"
+	"syscall"
+	"testing"
+)
+
+func TestNetCode(t *testing.T) {
+	cases := []struct {
+		err  error
+		code string
+	}{
+		{&net.OpError{Op: "dial", Err: os.NewSyscallError("connect", syscall.ECONNREFUSED)}, CodeNetRefused},
+		{&net.OpError{Op: "read", Err: syscall.ECONNRESET}, CodeNetReset},
+		{&net.DNSError{Err: "no such host", Name: "db.internal", IsNotFound: true}, CodeNetDNS},
+		{&net.DNSError{Err: "i/o timeout", Name: "db.internal", IsTimeout: true}, CodeNetTimeout},
+		{os.ErrDeadlineExceeded, CodeNetTimeout},
+		{errors.New("plain"), ""},
+	}
+	for _, c := range cases {
+		if got := NetCode(c.err); got != c.code {
+			t.Errorf("NetCode(%v) = %q, want %q", c.err, got, c.code)
+		}
+	}
+	if e := WrapNet(cases[0].err); e.Code != CodeNetRefused || !e.System || !IsRetryable(e) {
+		t.Errorf("got %v", e)
+	}
+	if WrapNet(errors.New("plain")) != nil {
+		t.Error("wrapped a non-network error")
+	}
+}
+
+func TestWrapFS(t *testing.T) {
+	_, err := os.Open("/nonexistent/baseError")
+	e := WrapFS(err)
+	if e.Code != CodeFSNotFound || e.System || e.Msg != "file not found" {
+		t.Fatalf("got %v", e)
+	}
+	if f := e.Fields(); f[FieldPath] != "/nonexistent/baseError" || f[FieldErrno] != int(syscall.ENOENT) {
+		t.Errorf("fields = %v", f)
+	}
+	prev := CurrentConfig()
+	defer Configure(prev)
+	SetOrigin(Origin{Service: "fs-test"})
+	full := &os.PathError{Op: "write", Path: "/data/out", Err: syscall.ENOSPC}
+	if e := WrapFS(full); e.Code != CodeFSDiskFull || !e.System || e.Origin().Service != "fs-test" {
+		t.Errorf("got %v, origin %+v", e, e.Origin())
+	}
+	if e := WrapFS(&os.PathError{Op: "open", Path: "x", Err: syscall.ENAMETOOLONG}); e.Code != CodeFSPathTooLong {
+		t.Errorf("got %v", e)
+	}
+	if WrapFS(errors.New("plain")) != nil {
+		t.Error("wrapped a non-fs error")
+	}
+}
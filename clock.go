@@ -0,0 +1,58 @@
+package baseError
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"time"
+)
+
+// Clock is the time source behind timestamps, latencies, expiries and retry history.
+type Clock interface {
+	Now() time.Time
+}
+
+// IDGenerator produces the reference IDs stamped by Sanitize.
+type IDGenerator interface {
+	NewID() string
+}
+
+type ClockFunc func() time.Time
+
+func (f ClockFunc) Now() time.Time { return f() }
+
+type IDGeneratorFunc func() string
+
+func (f IDGeneratorFunc) NewID() string { return f() }
+
+type systemClock struct{}
+
+func (systemClock) Now() time.Time { return time.Now() }
+
+type randomIDs struct{}
+
+func (randomIDs) NewID() string {
+	var buf [8]byte
+	rand.Read(buf[:])
+	return hex.EncodeToString(buf[:])
+}
+
+// Now is the time of the configured Clock. Adapters use it so that the latencies they
+// record follow the same clock as the core's.
+func Now() time.Time {
+	if c := cfg().Clock; c != nil {
+		return c.Now()
+	}
+	return time.Now()
+}
+
+// Since is the time elapsed since t on the configured Clock.
+func Since(t time.Time) time.Duration {
+	return Now().Sub(t)
+}
+
+func newID() string {
+	if g := cfg().IDs; g != nil {
+		return g.NewID()
+	}
+	return randomIDs{}.NewID()
+}
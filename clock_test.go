@@ -0,0 +1,35 @@
+package baseError
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestClockAndIDs(t *testing.T) {
+	prev := CurrentConfig()
+	defer Configure(prev)
+
+	at := time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)
+	c := CurrentConfig()
+	c.Clock = ClockFunc(func() time.Time { return at })
+	c.IDs = IDGeneratorFunc(func() string { return "ref-1" })
+	Configure(c)
+
+	if ref := Sanitize(errors.New("raw")).Fields()[FieldReference]; ref != "ref-1" {
+		t.Errorf("ref = %v", ref)
+	}
+	h := RetryHistory(nil).Add(New("A", ""))
+	if !h[0].At.Equal(at) {
+		t.Errorf("at = %v", h[0].At)
+	}
+	Suppress("TEST_CLOCK", time.Minute)
+	defer Unsuppress("TEST_CLOCK")
+	at = at.Add(2 * time.Minute)
+	if Suppressed(New("TEST_CLOCK", "")) {
+		t.Error("suppression ignored the clock")
+	}
+	if !Now().Equal(at) || Since(at.Add(-time.Second)) != time.Second {
+		t.Errorf("Now = %v", Now())
+	}
+}
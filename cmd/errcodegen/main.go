@@ -0,0 +1,69 @@
+// Command errcodegen generates client-side error code constants from a registry exported
+// with baseError's Registry.MarshalJSON:
+//
+//	errcodegen -in registry.json -lang go -pkg apierrors -out apierrors/codes.go
+//	errcodegen -in registry.json -lang ts -out src/errorCodes.ts
+//	errcodegen -in registry.json -lang java -pkg com.example.api -class ErrorCodes
+package main
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+
+	baseError "github.com/go-tron/base-error"
+	"github.com/go-tron/base-error/codegen"
+)
+
+func main() {
+	in := flag.String("in", "", "registry JSON file, stdin if empty")
+	out := flag.String("out", "", "output file, stdout if empty")
+	lang := flag.String("lang", "go", "go, ts or java")
+	pkg := flag.String("pkg", "errcodes", "Go or Java package")
+	class := flag.String("class", "ErrorCodes", "Java class name")
+	flag.Parse()
+
+	if err := run(*in, *out, *lang, *pkg, *class); err != nil {
+		fmt.Fprintln(os.Stderr, "errcodegen:", err)
+		os.Exit(1)
+	}
+}
+
+func run(in, out, lang, pkg, class string) error {
+	var data []byte
+	var err error
+	if in == "" {
+		data, err = io.ReadAll(os.Stdin)
+	} else {
+		data, err = os.ReadFile(in)
+	}
+	if err != nil {
+		return err
+	}
+	infos, err := baseError.ParseRegistry(data)
+	if err != nil {
+		return err
+	}
+
+	var buf bytes.Buffer
+	switch lang {
+	case "go":
+		err = codegen.Go(&buf, pkg, infos)
+	case "ts":
+		err = codegen.TypeScript(&buf, infos)
+	case "java":
+		err = codegen.Java(&buf, pkg, class, infos)
+	default:
+		err = fmt.Errorf("unknown language %q", lang)
+	}
+	if err != nil {
+		return err
+	}
+	if out == "" {
+		_, err = os.Stdout.Write(buf.Bytes())
+		return err
+	}
+	return os.WriteFile(out, buf.Bytes(), 0o644)
+}
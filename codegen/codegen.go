@@ -0,0 +1,193 @@
+// Package codegen generates client-side code constants from an exported registry, so API
+// consumers can switch on error codes without hardcoding strings. Go output is ready to
+// compile; TypeScript and Java output are plain text meant to be dropped into those SDKs.
+package codegen
+
+import (
+	"bytes"
+	"fmt"
+	"go/format"
+	"io"
+	"strings"
+	"text/template"
+	"unicode"
+
+	baseError "github.com/go-tron/base-error"
+)
+
+type entry struct {
+	Code     string
+	Name     string
+	Const    string
+	Template string
+}
+
+// entries names the codes of infos, failing on codes that map to the same identifier.
+func entries(infos []baseError.CodeInfo) ([]entry, error) {
+	seen := make(map[string]string, len(infos))
+	out := make([]entry, 0, len(infos))
+	for _, info := range infos {
+		name := identifier(info.Code)
+		if name == "" {
+			return nil, fmt.Errorf("codegen: code %q has no identifier", info.Code)
+		}
+		if prev, ok := seen[name]; ok {
+			return nil, fmt.Errorf("codegen: codes %q and %q both map to %s", prev, info.Code, name)
+		}
+		seen[name] = info.Code
+		out = append(out, entry{
+			Code:     info.Code,
+			Name:     name,
+			Const:    constName(info.Code),
+			Template: strings.Join(strings.Fields(info.Template), " "),
+		})
+	}
+	return out, nil
+}
+
+// identifier turns billing.CARD_DECLINED into BillingCardDeclined.
+func identifier(code string) string {
+	var b strings.Builder
+	for _, part := range strings.FieldsFunc(code, func(r rune) bool { return !unicode.IsLetter(r) && !unicode.IsDigit(r) }) {
+		lower := strings.ToLower(part)
+		b.WriteString(strings.ToUpper(lower[:1]) + lower[1:])
+	}
+	name := b.String()
+	if name != "" && unicode.IsDigit(rune(name[0])) {
+		name = "X" + name
+	}
+	return name
+}
+
+// constName turns billing.CARD_DECLINED into BILLING_CARD_DECLINED.
+func constName(code string) string {
+	parts := strings.FieldsFunc(code, func(r rune) bool { return !unicode.IsLetter(r) && !unicode.IsDigit(r) })
+	name := strings.ToUpper(strings.Join(parts, "_"))
+	if name != "" && unicode.IsDigit(rune(name[0])) {
+		name = "X" + name
+	}
+	return name
+}
+
+var goTemplate = template.Must(template.New("go").Parse(`// Code generated by errcodegen. DO NOT EDIT.
+
+package {{.Package}}
+
+import (
+	"errors"
+
+	baseError "github.com/go-tron/base-error"
+)
+
+// Code is an error code returned by the API.
+type Code string
+
+const (
+{{- range .Entries}}
+	{{- if .Template}}
+	// Code{{.Name}}: {{.Template}}
+	{{- end}}
+	Code{{.Name}} Code = {{printf "%q" .Code}}
+{{- end}}
+)
+
+// Codes lists every code the API returns.
+var Codes = []Code{
+{{- range .Entries}}
+	Code{{.Name}},
+{{- end}}
+}
+
+// Err returns a sentinel matching, via errors.Is, errors with code c.
+func (c Code) Err() error {
+	return baseError.CodeError(string(c))
+}
+
+// Is reports whether err carries code c.
+func (c Code) Is(err error) bool {
+	return errors.Is(err, c.Err())
+}
+{{range .Entries}}
+func Is{{.Name}}(err error) bool {
+	return Code{{.Name}}.Is(err)
+}
+{{end}}`))
+
+// Go writes a Go file for package pkg with a typed constant, and an Is helper, per code.
+func Go(w io.Writer, pkg string, infos []baseError.CodeInfo) error {
+	es, err := entries(infos)
+	if err != nil {
+		return err
+	}
+	var buf bytes.Buffer
+	if err := goTemplate.Execute(&buf, struct {
+		Package string
+		Entries []entry
+	}{pkg, es}); err != nil {
+		return err
+	}
+	src, err := format.Source(buf.Bytes())
+	if err != nil {
+		return fmt.Errorf("codegen: generated invalid Go: %w", err)
+	}
+	_, err = w.Write(src)
+	return err
+}
+
+var tsTemplate = template.Must(template.New("ts").Parse(`// Code generated by errcodegen. DO NOT EDIT.
+
+export const ErrorCodes = {
+{{- range .}}
+  {{.Name}}: {{printf "%q" .Code}},
+{{- end}}
+} as const;
+
+export type ErrorCode = (typeof ErrorCodes)[keyof typeof ErrorCodes];
+
+export interface CodedError {
+  code?: string;
+}
+{{range .}}
+export function is{{.Name}}(err: CodedError | null | undefined): boolean {
+  return err?.code === ErrorCodes.{{.Name}};
+}
+{{end}}`))
+
+// TypeScript writes a TypeScript module with an ErrorCodes object, an ErrorCode type and
+// an is helper per code, taking the decoded {"code","msg"} envelope.
+func TypeScript(w io.Writer, infos []baseError.CodeInfo) error {
+	es, err := entries(infos)
+	if err != nil {
+		return err
+	}
+	return tsTemplate.Execute(w, es)
+}
+
+var javaTemplate = template.Must(template.New("java").Parse(`// Code generated by errcodegen. DO NOT EDIT.
+{{if .Package}}
+package {{.Package}};
+{{end}}
+public final class {{.Class}} {
+    private {{.Class}}() {}
+{{range .Entries}}
+    public static final String {{.Const}} = {{printf "%q" .Code}};
+{{- end}}
+{{range .Entries}}
+    public static boolean is{{.Name}}(String code) {
+        return {{.Const}}.equals(code);
+    }
+{{end}}}
+`))
+
+// Java writes a final class of String constants with an is helper per code.
+func Java(w io.Writer, pkg string, class string, infos []baseError.CodeInfo) error {
+	es, err := entries(infos)
+	if err != nil {
+		return err
+	}
+	return javaTemplate.Execute(w, struct {
+		Package string
+		Class   string
+		Entries []entry
+	}{pkg, class, es})
+}
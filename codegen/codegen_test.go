@@ -0,0 +1,56 @@
+package codegen
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	baseError "github.com/go-tron/base-error"
+)
+
+var infos = []baseError.CodeInfo{
+	{Code: "ORDER_NOT_FOUND", Template: "order {} not found"},
+	{Code: "billing.CARD_DECLINED"},
+}
+
+func TestGo(t *testing.T) {
+	var buf bytes.Buffer
+	if err := Go(&buf, "apierrors", infos); err != nil {
+		t.Fatal(err)
+	}
+	src := buf.String()
+	for _, want := range []string{
+		"package apierrors",
+		"// CodeOrderNotFound: order {} not found\n",
+		`CodeOrderNotFound       Code = "ORDER_NOT_FOUND"`,
+		`CodeBillingCardDeclined Code = "billing.CARD_DECLINED"`,
+		"func IsBillingCardDeclined(err error) bool {",
+	} {
+		if !strings.Contains(src, want) {
+			t.Errorf("missing %q in\n%s", want, src)
+		}
+	}
+}
+
+func TestTypeScriptAndJava(t *testing.T) {
+	var ts, java bytes.Buffer
+	if err := TypeScript(&ts, infos); err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(ts.String(), `BillingCardDeclined: "billing.CARD_DECLINED",`) || !strings.Contains(ts.String(), "export function isOrderNotFound(") {
+		t.Errorf("ts:\n%s", ts.String())
+	}
+	if err := Java(&java, "com.example.api", "ErrorCodes", infos); err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(java.String(), `public static final String BILLING_CARD_DECLINED = "billing.CARD_DECLINED";`) || !strings.Contains(java.String(), "package com.example.api;") {
+		t.Errorf("java:\n%s", java.String())
+	}
+}
+
+func TestCollision(t *testing.T) {
+	err := Go(&bytes.Buffer{}, "x", []baseError.CodeInfo{{Code: "A_B"}, {Code: "a.b"}})
+	if err == nil || !strings.Contains(err.Error(), "both map to AB") {
+		t.Errorf("err = %v", err)
+	}
+}
@@ -0,0 +1,46 @@
+package baseError
+
+import (
+	"context"
+	"sync"
+)
+
+// Collector gathers errors from concurrently running functions.
+type Collector struct {
+	mu   sync.Mutex
+	wg   sync.WaitGroup
+	errs []error
+}
+
+func (c *Collector) Add(err error) {
+	if isNil(err) {
+		return
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.errs = append(c.errs, err)
+}
+
+func (c *Collector) Errors() []error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return append([]error(nil), c.errs...)
+}
+
+func (c *Collector) Go(fn func() error) {
+	c.wg.Add(1)
+	go func() {
+		defer c.wg.Done()
+		c.Add(run(fn))
+	}()
+}
+
+func (c *Collector) GoCtx(ctx context.Context, fn func(ctx context.Context) error) {
+	c.Go(bindCtx(ctx, fn))
+}
+
+// Wait blocks until every function started with Go or GoCtx has returned.
+func (c *Collector) Wait() []error {
+	c.wg.Wait()
+	return c.Errors()
+}
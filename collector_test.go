@@ -0,0 +1,22 @@
+package baseError
+
+import (
+	"context"
+	"testing"
+)
+
+func TestCollector(t *testing.T) {
+	var c Collector
+	c.Go(func() error { return New("A", "") })
+	c.Go(func() error { return nil })
+	c.Go(explode)
+	c.GoCtx(context.Background(), func(context.Context) error { return New("B", "") })
+
+	codes := map[string]bool{}
+	for _, err := range c.Wait() {
+		codes[CodeOf(err)] = true
+	}
+	if len(codes) != 3 || !codes["A"] || !codes["B"] || !codes[CodePanic] {
+		t.Errorf("codes = %v", codes)
+	}
+}
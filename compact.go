@@ -0,0 +1,85 @@
+package baseError
+
+import (
+	"bytes"
+	"compress/flate"
+	"encoding/base64"
+	"errors"
+	"io"
+)
+
+const compactVersion = 1
+
+var (
+	ErrCompactVersion  = errors.New("baseError: unsupported compact envelope version")
+	ErrCompactTooLarge = errors.New("baseError: compact envelope exceeds size limit")
+)
+
+// maxCompactDecoded bounds the inflated size accepted by DecodeCompact.
+const maxCompactDecoded = 1 << 20
+
+// EncodeCompact encodes err's envelope as URL-safe base64 of a version byte followed by
+// the deflated JSON, for HTTP headers, gRPC metadata and AMQP headers. If the result would
+// exceed limit bytes the envelope is truncated as by MarshalLimited until it fits. A limit
+// of zero or less means unlimited. A nil err encodes as the empty string, which
+// DecodeCompact turns back into nil.
+func EncodeCompact(err *Error, limit int) (string, error) {
+	if err == nil {
+		return "", nil
+	}
+	jsonLimit := 0
+	for {
+		buf, merr := err.marshal(jsonLimit)
+		if merr != nil {
+			return "", merr
+		}
+		s, cerr := compress(buf)
+		if cerr != nil {
+			return "", cerr
+		}
+		if limit <= 0 || len(s) <= limit {
+			return s, nil
+		}
+		if jsonLimit == 0 {
+			jsonLimit = len(buf)
+		}
+		jsonLimit = jsonLimit * 3 / 4
+		if jsonLimit < len(`{"code":"","msg":"","truncated":true}`)+len(err.Code) {
+			return "", ErrCompactTooLarge
+		}
+	}
+}
+
+func compress(buf []byte) (string, error) {
+	var out bytes.Buffer
+	out.WriteByte(compactVersion)
+	w, _ := flate.NewWriter(&out, flate.BestCompression)
+	if _, err := w.Write(buf); err != nil {
+		return "", err
+	}
+	if err := w.Close(); err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(out.Bytes()), nil
+}
+
+func DecodeCompact(s string) (*Error, error) {
+	if s == "" {
+		return nil, nil
+	}
+	raw, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return nil, err
+	}
+	if len(raw) == 0 || raw[0] != compactVersion {
+		return nil, ErrCompactVersion
+	}
+	buf, err := io.ReadAll(io.LimitReader(flate.NewReader(bytes.NewReader(raw[1:])), maxCompactDecoded+1))
+	if err != nil {
+		return nil, err
+	}
+	if len(buf) > maxCompactDecoded {
+		return nil, ErrCompactTooLarge
+	}
+	return DecodeAny(buf)
+}
@@ -0,0 +1,33 @@
+package baseError
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestCompact(t *testing.T) {
+	s, err := EncodeCompact(New("ORDER_LOCKED", "order is locked by another checkout"), 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	e, err := DecodeCompact(s)
+	if err != nil || e.Code != "ORDER_LOCKED" || e.Msg != "order is locked by another checkout" {
+		t.Fatalf("got %v %v", e, err)
+	}
+
+	big := New("BATCH_INVALID", strings.Repeat("row 12 has an invalid amount; ", 400))
+	s, err = EncodeCompact(big, 96)
+	if err != nil || len(s) > 96 {
+		t.Fatalf("len=%d err=%v", len(s), err)
+	}
+	if e, err := DecodeCompact(s); err != nil || e.Code != "BATCH_INVALID" {
+		t.Errorf("got %v %v", e, err)
+	}
+
+	if _, err := DecodeCompact("AA"); err != ErrCompactVersion {
+		t.Errorf("got %v", err)
+	}
+	if _, err := EncodeCompact(big, 8); err != ErrCompactTooLarge {
+		t.Errorf("got %v", err)
+	}
+}
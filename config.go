@@ -0,0 +1,152 @@
+package baseError
+
+import (
+	"io"
+	"os"
+	"strconv"
+	"sync/atomic"
+)
+
+// Config holds every package-level setting. Applications set it once at startup with
+// Configure; tests snapshot it with CurrentConfig and restore the snapshot afterwards.
+type Config struct {
+	// DefaultWrapCode is the code CodeOf reports for errors that carry no *Error.
+	DefaultWrapCode string
+	// StackDepth is the number of frames captured by helpers that don't take a depth.
+	// It is also the AutoDepth fallback when no depth is registered for the severity.
+	StackDepth int
+	// PanicStackDepth is the number of frames captured by FromPanic.
+	PanicStackDepth int
+	// DisableStacks turns every stack capture into a no-op; see DisableStacks.
+	DisableStacks bool
+	// SourceDirs are skipped by Callers when looking for the first caller frame.
+	SourceDirs []string
+
+	// JSONCauseTree makes MarshalJSON include the top frame and nested cause tree.
+	JSONCauseTree bool
+	// MaxEnvelopeSize caps the bytes produced by MarshalJSON; zero means unlimited.
+	MaxEnvelopeSize int
+	// NormalizeMessages NFC-normalizes messages when errors are created, so composed and
+	// decomposed forms of the same text render and compare alike.
+	NormalizeMessages bool
+
+	// SanitizedCode and SanitizedMsg replace System errors without a registered public
+	// code in Sanitize.
+	SanitizedCode string
+	SanitizedMsg  string
+
+	// ProblemTypeBase prefixes the code to form the Problem Details "type" URI.
+	ProblemTypeBase string
+
+	// Origin is stamped on System errors.
+	Origin Origin
+
+	Hooks           []Hook
+	MetricsRecorder MetricsRecorder
+	// Classifiers are consulted first by Classify; see AddClassifier.
+	Classifiers []Classifier
+
+	// TraceConstruction logs every error creation with its caller to TraceWriter, or
+	// stderr if it is nil. See SetTraceConstruction.
+	TraceConstruction bool
+	TraceWriter       io.Writer
+
+	// LogStack makes LogValue include the stack frames.
+	LogStack bool
+
+	// Strict makes constructors panic on misuse; see SetStrict.
+	Strict bool
+
+	// ProvisionalCodes makes Sanitize count foreign errors by ProvisionalCode.
+	ProvisionalCodes bool
+
+	// Priority ranks errors for Prioritize, higher first; nil means DefaultPriority.
+	Priority func(err error) int
+
+	// Clock and IDs replace the system clock and random reference IDs, for deterministic
+	// tests or environments with their own monotonic sources.
+	Clock Clock
+	IDs   IDGenerator
+}
+
+var config atomic.Pointer[Config]
+
+func defaultConfig() *Config {
+	return &Config{
+		DefaultWrapCode: "UNKNOWN",
+		StackDepth:      32,
+		PanicStackDepth: 32,
+		DisableStacks:   envBool("BASE_ERROR_DISABLE_STACKS"),
+		SourceDirs:      []string{sourceDir},
+		SanitizedCode:   "INTERNAL_ERROR",
+		SanitizedMsg:    "internal error",
+		ProblemTypeBase: "/problems/",
+		Clock:           systemClock{},
+		IDs:             randomIDs{},
+		Origin: Origin{
+			Service: os.Getenv("SERVICE_NAME"),
+			Version: os.Getenv("SERVICE_VERSION"),
+			Zone:    os.Getenv("SERVICE_ZONE"),
+		},
+	}
+}
+
+func cfg() *Config {
+	if c := config.Load(); c != nil {
+		return c
+	}
+	config.CompareAndSwap(nil, defaultConfig())
+	return config.Load()
+}
+
+// Configure atomically replaces the whole configuration.
+func Configure(c Config) {
+	config.Store(c.clone())
+}
+
+// CurrentConfig returns a copy of the active configuration.
+func CurrentConfig() Config {
+	return *cfg().clone()
+}
+
+// DefaultConfig returns the configuration the package starts with.
+func DefaultConfig() Config {
+	return *defaultConfig()
+}
+
+func updateConfig(fn func(c *Config)) {
+	for {
+		old := cfg()
+		c := old.clone()
+		fn(c)
+		if config.CompareAndSwap(old, c) {
+			return
+		}
+	}
+}
+
+func (c *Config) clone() *Config {
+	out := *c
+	out.SourceDirs = append([]string(nil), c.SourceDirs...)
+	out.Hooks = append([]Hook(nil), c.Hooks...)
+	out.Classifiers = append([]Classifier(nil), c.Classifiers...)
+	return &out
+}
+
+// DisableStacks makes every constructor, including the *Stack ones and FromPanic, skip
+// stack capture process-wide, for benchmarks and latency-sensitive deployments. Setting
+// the BASE_ERROR_DISABLE_STACKS environment variable to true does the same at startup.
+func DisableStacks() {
+	updateConfig(func(c *Config) {
+		c.DisableStacks = true
+	})
+}
+
+func envBool(name string) bool {
+	on, _ := strconv.ParseBool(os.Getenv(name))
+	return on
+}
+
+func DefaultStackDepth() int {
+	return cfg().StackDepth
+}
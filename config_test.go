@@ -0,0 +1,62 @@
+package baseError
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"testing"
+)
+
+func TestConfigure(t *testing.T) {
+	prev := CurrentConfig()
+	defer Configure(prev)
+
+	c := DefaultConfig()
+	c.DefaultWrapCode = "INTERNAL"
+	c.StackDepth = 4
+	Configure(c)
+	if DefaultWrapCode() != "INTERNAL" || DefaultStackDepth() != 4 {
+		t.Errorf("config not applied: %+v", CurrentConfig())
+	}
+
+	snapshot := CurrentConfig()
+	snapshot.Hooks = append(snapshot.Hooks, func(error) {})
+	if len(CurrentConfig().Hooks) != len(c.Hooks) {
+		t.Error("CurrentConfig must return a copy")
+	}
+
+	var wg sync.WaitGroup
+	for i := 0; i < 50; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			AddHook(func(error) {})
+		}()
+	}
+	wg.Wait()
+	if n := len(CurrentConfig().Hooks); n != len(c.Hooks)+50 {
+		t.Errorf("hooks = %d", n)
+	}
+}
+
+func TestDisableStacks(t *testing.T) {
+	prev := CurrentConfig()
+	defer Configure(prev)
+
+	DisableStacks()
+	if e := NewStack("NO_STACK", "", 32); e.Stack() != nil || len(StackFrames(e)) != 0 {
+		t.Errorf("stack captured: %v", StackFrames(e))
+	}
+	if e := FromPanic("boom"); e.Stack() != nil {
+		t.Error("panic stack captured")
+	}
+	w := WithStack(errors.New("eof"), 32)
+	if s := fmt.Sprintf("%+v", w); s != "eof" {
+		t.Errorf("format = %q", s)
+	}
+
+	t.Setenv("BASE_ERROR_DISABLE_STACKS", "true")
+	if !DefaultConfig().DisableStacks {
+		t.Error("environment override ignored")
+	}
+}
@@ -0,0 +1,59 @@
+package baseError
+
+// constructors is the constructor set behind Scope and Namespace. Factory codes are
+// registered in registry, stack depths follow the policy of depths and the config,
+// codes are qualified by code, and every error goes through finish before the creation
+// hooks run.
+type constructors struct {
+	registry *Registry
+	depths   *Registry
+	config   func() *Config
+	code     func(string) string
+	finish   func(*Error)
+}
+
+func (c *constructors) created(e *Error) *Error {
+	c.finish(e)
+	return created(e)
+}
+
+func (c *constructors) new(code string, msg string, system bool) *Error {
+	return c.created(&Error{Code: c.code(code), Msg: msg, System: system})
+}
+
+func (c *constructors) newStack(code string, msg string, system bool, depth int) *Error {
+	code = c.code(code)
+	depth = stackDepth(c.depths, c.config(), code, system, depth)
+	return c.created(&Error{Code: code, Msg: msg, System: system, stack: Callers(3, depth)})
+}
+
+// factory builds the factories of the set; depth is only used when stacked.
+func (c *constructors) factory(arg []string, system bool, stacked bool, depth int) func(...interface{}) *Error {
+	code, formatter := factoryFormat(arg...)
+	code = c.code(code)
+	registerFactory(c.registry, c.config(), code, arg)
+	return func(message ...interface{}) *Error {
+		msg, args := formatter(message...)
+		e := &Error{Code: code, Msg: msg, args: args, System: system}
+		if stacked {
+			e.stack = Callers(3, stackDepth(c.depths, c.config(), code, system, depth))
+		}
+		return c.created(e)
+	}
+}
+
+func (c *constructors) wrap(code string, err error) *Error {
+	if isNil(err) {
+		return nil
+	}
+	return c.created(&Error{Code: c.code(code), Msg: err.Error(), System: true, cause: err})
+}
+
+func (c *constructors) wrapStack(code string, err error, depth int) *Error {
+	if isNil(err) {
+		return nil
+	}
+	code = c.code(code)
+	depth = stackDepth(c.depths, c.config(), code, true, depth)
+	return c.created(&Error{Code: code, Msg: err.Error(), System: true, cause: err, stack: Callers(3, depth)})
+}
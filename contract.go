@@ -0,0 +1,94 @@
+package baseError
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+type registryJSON struct {
+	Codes []CodeInfo `json:"codes"`
+}
+
+// Export returns the info of every code known to r and its mounted registries, sorted
+// by code.
+func (r *Registry) Export() []CodeInfo {
+	codes := r.Codes()
+	out := make([]CodeInfo, 0, len(codes))
+	for _, code := range codes {
+		if info, ok := r.Lookup(code); ok {
+			out = append(out, info)
+		}
+	}
+	return out
+}
+
+// MarshalJSON encodes r as {"codes":[...]}, the form CheckContract and ParseRegistry read.
+func (r *Registry) MarshalJSON() ([]byte, error) {
+	return json.Marshal(registryJSON{Codes: r.Export()})
+}
+
+// ParseRegistry decodes a registry exported with MarshalJSON.
+func ParseRegistry(data []byte) ([]CodeInfo, error) {
+	var out registryJSON
+	if err := json.Unmarshal(data, &out); err != nil {
+		return nil, err
+	}
+	return out.Codes, nil
+}
+
+// ContractReport compares the codes a server can return with those a client handles.
+type ContractReport struct {
+	// Unhandled codes are exported by the server but not handled by the client.
+	Unhandled []string
+	// Obsolete codes are handled by the client but no longer exported by the server.
+	Obsolete []string
+}
+
+// CheckContract compares the registry JSON exported by a server with the codes a client
+// SDK handles.
+func CheckContract(registry []byte, handled []string) (ContractReport, error) {
+	infos, err := ParseRegistry(registry)
+	if err != nil {
+		return ContractReport{}, err
+	}
+	server := make(map[string]bool, len(infos))
+	for _, info := range infos {
+		server[info.Code] = true
+	}
+	client := make(map[string]bool, len(handled))
+	for _, code := range handled {
+		client[code] = true
+	}
+	var report ContractReport
+	for code := range server {
+		if !client[code] {
+			report.Unhandled = append(report.Unhandled, code)
+		}
+	}
+	for code := range client {
+		if !server[code] {
+			report.Obsolete = append(report.Obsolete, code)
+		}
+	}
+	sort.Strings(report.Unhandled)
+	sort.Strings(report.Obsolete)
+	return report, nil
+}
+
+// Err returns nil if the client handles exactly the server's codes, and an error listing
+// the differences otherwise.
+func (r ContractReport) Err() error {
+	if len(r.Unhandled) == 0 && len(r.Obsolete) == 0 {
+		return nil
+	}
+	var parts []string
+	if len(r.Unhandled) > 0 {
+		parts = append(parts, "unhandled: "+strings.Join(r.Unhandled, ", "))
+	}
+	if len(r.Obsolete) > 0 {
+		parts = append(parts, "obsolete: "+strings.Join(r.Obsolete, ", "))
+	}
+	return fmt.Errorf("baseError: client contract mismatch: %s", strings.Join(parts, "; "))
+}
@@ -0,0 +1,42 @@
+package baseError
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestCheckContract(t *testing.T) {
+	r := NewRegistry()
+	r.SetHTTPStatus("CONTRACT_ORDER_NOT_FOUND", 404)
+	r.SetRetryable("CONTRACT_BUSY", true)
+	r.SetTemplate("CONTRACT_LIMIT", "limit {} exceeded")
+	sub := NewSubRegistry("billing")
+	sub.SetSeverity("CARD_DECLINED", SeverityInfo)
+	if err := r.Mount(sub); err != nil {
+		t.Fatal(err)
+	}
+
+	buf, err := json.Marshal(r)
+	if err != nil {
+		t.Fatal(err)
+	}
+	infos, err := ParseRegistry(buf)
+	if err != nil || len(infos) != 4 || infos[0].Code != "CONTRACT_BUSY" || !infos[0].Retryable || infos[3].Code != "billing.CARD_DECLINED" {
+		t.Fatalf("infos = %+v, %v", infos, err)
+	}
+
+	report, err := CheckContract(buf, []string{"CONTRACT_BUSY", "CONTRACT_ORDER_NOT_FOUND", "CONTRACT_GONE"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := report.Err(); got == nil || got.Error() != "baseError: client contract mismatch: unhandled: CONTRACT_LIMIT, billing.CARD_DECLINED; obsolete: CONTRACT_GONE" {
+		t.Errorf("err = %v", got)
+	}
+	report, _ = CheckContract(buf, []string{"CONTRACT_BUSY", "CONTRACT_ORDER_NOT_FOUND", "CONTRACT_LIMIT", "billing.CARD_DECLINED"})
+	if report.Err() != nil {
+		t.Errorf("exhaustive client reported %v", report.Err())
+	}
+	if _, err := CheckContract([]byte("{"), nil); err == nil {
+		t.Error("bad JSON accepted")
+	}
+}
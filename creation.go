@@ -0,0 +1,46 @@
+package baseError
+
+import "sync"
+
+type creationHook struct {
+	fn func(e *Error)
+}
+
+var creationHooks struct {
+	sync.RWMutex
+	list []*creationHook
+}
+
+// AddCreationHook registers fn to be called with every *Error built by this package's
+// constructors and factories. The returned function unregisters it.
+func AddCreationHook(fn func(e *Error)) (remove func()) {
+	h := &creationHook{fn: fn}
+	creationHooks.Lock()
+	creationHooks.list = append(creationHooks.list, h)
+	creationHooks.Unlock()
+	return func() {
+		creationHooks.Lock()
+		defer creationHooks.Unlock()
+		for i, x := range creationHooks.list {
+			if x == h {
+				creationHooks.list = append(creationHooks.list[:i:i], creationHooks.list[i+1:]...)
+				return
+			}
+		}
+	}
+}
+
+func runCreationHooks(e *Error) {
+	creationHooks.RLock()
+	list := creationHooks.list
+	creationHooks.RUnlock()
+	for _, h := range list {
+		h.fn(e)
+	}
+}
+
+// WithoutStack drops the captured stack.
+func (b *Error) WithoutStack() *Error {
+	b.stack = nil
+	return b
+}
@@ -0,0 +1,88 @@
+package baseError
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+const (
+	FieldTimeoutName      = "timeout_name"
+	FieldTimeout          = "timeout"
+	FieldTimeoutRemaining = "timeout_remaining"
+)
+
+type timeoutKey struct{}
+
+type timeoutInfo struct {
+	name     string
+	timeout  time.Duration
+	deadline time.Time
+	parent   *timeoutInfo
+}
+
+// WithTimeoutInfo is context.WithTimeout that also names the timeout, so errors wrapped
+// with WrapDeadline or WrapFunc can tell which of several nested timeouts fired.
+func WithTimeoutInfo(ctx context.Context, name string, timeout time.Duration) (context.Context, context.CancelFunc) {
+	parent, _ := ctx.Value(timeoutKey{}).(*timeoutInfo)
+	outer, bounded := ctx.Deadline()
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	info := &timeoutInfo{name: name, timeout: timeout, parent: parent}
+	// A timeout longer than an enclosing one can never fire; leave its deadline zero.
+	if deadline, _ := ctx.Deadline(); !bounded || !deadline.Equal(outer) {
+		info.deadline = deadline
+	}
+	return context.WithValue(ctx, timeoutKey{}, info), cancel
+}
+
+// WrapDeadline wraps err like WrapStack and, if it is a deadline error, records the name
+// and configured value of the timeout that fired and the budget left on ctx. A timeout
+// that was not set through WithTimeoutInfo is reported by its remaining budget only. An
+// *Error is annotated on a copy, so err itself is never modified.
+func WrapDeadline(ctx context.Context, code string, err error) *Error {
+	if isNil(err) {
+		return nil
+	}
+	e, ok := err.(*Error)
+	if !ok {
+		e = WrapStack(code, err, AutoDepth)
+	} else if causedBy(e, context.DeadlineExceeded, 0) {
+		e = e.Copy()
+	}
+	attributeDeadline(ctx, e)
+	return e
+}
+
+func attributeDeadline(ctx context.Context, e *Error) {
+	if !causedBy(e, context.DeadlineExceeded, 0) {
+		return
+	}
+	deadline, ok := ctx.Deadline()
+	if !ok {
+		return
+	}
+	e.WithField(FieldTimeoutRemaining, time.Until(deadline))
+	for info, _ := ctx.Value(timeoutKey{}).(*timeoutInfo); info != nil; info = info.parent {
+		if info.deadline.Equal(deadline) {
+			e.WithField(FieldTimeoutName, info.name)
+			e.WithField(FieldTimeout, info.timeout)
+			return
+		}
+	}
+}
+
+// causedBy is errors.Is extended to the Cause chains of *Error and pkg/errors values.
+func causedBy(err error, target error, depth int) bool {
+	if errors.Is(err, target) {
+		return true
+	}
+	if depth == maxCauseDepth {
+		return false
+	}
+	for _, c := range causes(err) {
+		if causedBy(c, target, depth+1) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,46 @@
+package baseError
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestWrapDeadline(t *testing.T) {
+	ctx, cancel := WithTimeoutInfo(context.Background(), "request", time.Hour)
+	defer cancel()
+	ctx, cancel2 := WithTimeoutInfo(ctx, "db", time.Millisecond)
+	defer cancel2()
+	ctx, cancel3 := WithTimeoutInfo(ctx, "cache", time.Minute)
+	defer cancel3()
+	<-ctx.Done()
+
+	db := Wrap("DB", ctx.Err())
+	e := WrapDeadline(ctx, "QUERY_TIMEOUT", db)
+	if len(db.Fields()) != 0 {
+		t.Errorf("argument annotated: %v", db.Fields())
+	}
+	f := e.Fields()
+	if f[FieldTimeoutName] != "db" || f[FieldTimeout] != time.Millisecond {
+		t.Errorf("fields = %v", f)
+	}
+	if r, _ := f[FieldTimeoutRemaining].(time.Duration); r > 0 {
+		t.Errorf("remaining = %v", r)
+	}
+
+	if e := WrapDeadline(ctx, "X", New("OTHER", "x")); len(e.Fields()) != 0 {
+		t.Errorf("non-deadline error annotated: %v", e.Fields())
+	}
+}
+
+func TestWrapFuncDeadline(t *testing.T) {
+	ctx, cancel := WithTimeoutInfo(context.Background(), "upstream", time.Millisecond)
+	defer cancel()
+	call := WrapFunc("fetch", "FETCH_FAILED", func(ctx context.Context) (int, error) {
+		<-ctx.Done()
+		return 0, ctx.Err()
+	})
+	if _, e := call(ctx); e.Fields()[FieldTimeoutName] != "upstream" {
+		t.Errorf("fields = %v", e.Fields())
+	}
+}
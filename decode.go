@@ -0,0 +1,173 @@
+package baseError
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"path"
+	"strconv"
+	"strings"
+)
+
+var ErrUnknownEnvelope = errors.New("baseError: unrecognized error envelope")
+
+var grpcCodeNames = []string{
+	"OK",
+	"CANCELLED",
+	"UNKNOWN",
+	"INVALID_ARGUMENT",
+	"DEADLINE_EXCEEDED",
+	"NOT_FOUND",
+	"ALREADY_EXISTS",
+	"PERMISSION_DENIED",
+	"RESOURCE_EXHAUSTED",
+	"FAILED_PRECONDITION",
+	"ABORTED",
+	"OUT_OF_RANGE",
+	"UNIMPLEMENTED",
+	"INTERNAL",
+	"UNAVAILABLE",
+	"DATA_LOSS",
+	"UNAUTHENTICATED",
+}
+
+// DecodeAny sniffs the shape of body and normalizes it to *Error. Recognized shapes are
+// our own {"code","msg"} envelope, RFC 7807 Problem Details, JSON:API error documents,
+// OAuth 2.0 error responses and grpc-gateway status bodies.
+func DecodeAny(body []byte) (*Error, error) {
+	var doc map[string]json.RawMessage
+	if err := json.Unmarshal(body, &doc); err != nil {
+		return nil, err
+	}
+
+	if raw, ok := doc["errors"]; ok {
+		return decodeJSONAPI(raw)
+	}
+	if raw, ok := doc["error"]; ok {
+		var s string
+		if json.Unmarshal(raw, &s) == nil {
+			return decodeOAuth(doc, s), nil
+		}
+		return DecodeAny(raw)
+	}
+	for _, k := range []string{"type", "title", "status", "detail"} {
+		if _, ok := doc[k]; ok {
+			return decodeProblem(doc, body)
+		}
+	}
+	if raw, ok := doc["code"]; ok {
+		var n int
+		if json.Unmarshal(raw, &n) == nil {
+			return decodeGateway(body)
+		}
+		return Parse(body)
+	}
+	return nil, ErrUnknownEnvelope
+}
+
+func decodeProblem(doc map[string]json.RawMessage, body []byte) (*Error, error) {
+	var p struct {
+		Type   string `json:"type"`
+		Title  string `json:"title"`
+		Status int    `json:"status"`
+		Detail string `json:"detail"`
+	}
+	if err := json.Unmarshal(body, &p); err != nil {
+		return nil, err
+	}
+
+	var code string
+	if raw, ok := doc["code"]; ok {
+		json.Unmarshal(raw, &code)
+	}
+	if code == "" && p.Type != "" && p.Type != "about:blank" {
+		code = path.Base(p.Type)
+	}
+	if code == "" {
+		code = httpStatusCode(p.Status)
+	}
+	msg := p.Detail
+	if msg == "" {
+		msg = p.Title
+	}
+	return &Error{Code: code, Msg: msg, System: p.Status >= 500}, nil
+}
+
+func decodeJSONAPI(raw json.RawMessage) (*Error, error) {
+	var errs []struct {
+		Status string `json:"status"`
+		Code   string `json:"code"`
+		Title  string `json:"title"`
+		Detail string `json:"detail"`
+	}
+	if err := json.Unmarshal(raw, &errs); err != nil {
+		return nil, err
+	}
+	if len(errs) == 0 {
+		return nil, ErrUnknownEnvelope
+	}
+
+	e := errs[0]
+	status, _ := strconv.Atoi(e.Status)
+	code := e.Code
+	if code == "" {
+		code = httpStatusCode(status)
+	}
+	msg := e.Detail
+	if msg == "" {
+		msg = e.Title
+	}
+	return &Error{Code: code, Msg: msg, System: status >= 500}, nil
+}
+
+func decodeOAuth(doc map[string]json.RawMessage, code string) *Error {
+	var msg string
+	if raw, ok := doc["error_description"]; ok {
+		json.Unmarshal(raw, &msg)
+	}
+	if msg == "" {
+		msg = code
+	}
+	return &Error{Code: code, Msg: msg, System: code == "server_error" || code == "temporarily_unavailable"}
+}
+
+func decodeGateway(body []byte) (*Error, error) {
+	var s struct {
+		Code    int    `json:"code"`
+		Message string `json:"message"`
+		Details []struct {
+			Type   string `json:"@type"`
+			Reason string `json:"reason"`
+		} `json:"details"`
+	}
+	if err := json.Unmarshal(body, &s); err != nil {
+		return nil, err
+	}
+
+	code := grpcCodeName(s.Code)
+	for _, d := range s.Details {
+		if strings.HasSuffix(d.Type, "google.rpc.ErrorInfo") && d.Reason != "" {
+			code = d.Reason
+			break
+		}
+	}
+	switch grpcCodeName(s.Code) {
+	case "UNKNOWN", "INTERNAL", "UNAVAILABLE", "DATA_LOSS":
+		return &Error{Code: code, Msg: s.Message, System: true}, nil
+	}
+	return &Error{Code: code, Msg: s.Message}, nil
+}
+
+func grpcCodeName(c int) string {
+	if c >= 0 && c < len(grpcCodeNames) {
+		return grpcCodeNames[c]
+	}
+	return "UNKNOWN"
+}
+
+func httpStatusCode(status int) string {
+	if text := http.StatusText(status); text != "" {
+		return strings.ToUpper(strings.ReplaceAll(text, " ", "_"))
+	}
+	return "UNKNOWN"
+}
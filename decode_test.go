@@ -0,0 +1,34 @@
+package baseError
+
+import "testing"
+
+func TestDecodeAny(t *testing.T) {
+	cases := []struct {
+		body   string
+		code   string
+		msg    string
+		system bool
+	}{
+		{`{"code":"USER_NOT_FOUND","msg":"user not found"}`, "USER_NOT_FOUND", "user not found", false},
+		{`{"error":{"code":"USER_NOT_FOUND","msg":"user not found"}}`, "USER_NOT_FOUND", "user not found", false},
+		{`{"type":"https://example.com/probs/out-of-credit","title":"Out of credit","status":403,"detail":"balance is 30"}`, "out-of-credit", "balance is 30", false},
+		{`{"title":"Service Unavailable","status":503}`, "SERVICE_UNAVAILABLE", "Service Unavailable", true},
+		{`{"errors":[{"status":"422","code":"INVALID_NAME","title":"Invalid name"}]}`, "INVALID_NAME", "Invalid name", false},
+		{`{"error":"invalid_grant","error_description":"refresh token expired"}`, "invalid_grant", "refresh token expired", false},
+		{`{"code":5,"message":"order not found","details":[]}`, "NOT_FOUND", "order not found", false},
+		{`{"code":13,"message":"boom","details":[{"@type":"type.googleapis.com/google.rpc.ErrorInfo","reason":"DB_DOWN"}]}`, "DB_DOWN", "boom", true},
+	}
+	for _, c := range cases {
+		err, derr := DecodeAny([]byte(c.body))
+		if derr != nil {
+			t.Fatalf("%s: %v", c.body, derr)
+		}
+		if err.Code != c.code || err.Msg != c.msg || err.System != c.system {
+			t.Errorf("%s: got %s %q system=%v", c.body, err.Code, err.Msg, err.System)
+		}
+	}
+
+	if _, err := DecodeAny([]byte(`{"foo":1}`)); err != ErrUnknownEnvelope {
+		t.Errorf("expected ErrUnknownEnvelope, got %v", err)
+	}
+}
@@ -0,0 +1,128 @@
+package baseError
+
+import (
+	"context"
+	"errors"
+	"sync"
+)
+
+const (
+	CodeCanceled         = "CANCELLED"
+	CodeDeadlineExceeded = "DEADLINE_EXCEEDED"
+
+	FieldDependency = "dep"
+)
+
+func init() {
+	RegisterRetryable(CodeDeadlineExceeded, true)
+}
+
+// Classifier returns the code for err, or "" if it doesn't recognize it.
+type Classifier func(err error) string
+
+// AddClassifier makes Classify consult c before the built-in classifiers, e.g.
+// AddClassifier(sqlerr.Classify).
+func AddClassifier(c Classifier) {
+	updateConfig(func(cfg *Config) {
+		cfg.Classifiers = append(cfg.Classifiers, c)
+	})
+}
+
+// Classify returns the code of err: its own for *Error values, otherwise the first code
+// given by a registered classifier, the context error codes or NetCode, falling back to
+// Config.DefaultWrapCode.
+func Classify(err error) string {
+	if isNil(err) {
+		return ""
+	}
+	if e, ok := err.(*Error); ok {
+		return e.Code
+	}
+	c := cfg()
+	for _, classify := range c.Classifiers {
+		if code := classify(err); code != "" {
+			return code
+		}
+	}
+	switch {
+	case errors.Is(err, context.DeadlineExceeded):
+		return CodeDeadlineExceeded
+	case errors.Is(err, context.Canceled):
+		return CodeCanceled
+	}
+	if code := NetCode(err); code != "" {
+		return code
+	}
+	return c.DefaultWrapCode
+}
+
+// Dependencies tracks the outcome of calls to downstream dependencies for health checks
+// and, if budget is set, the error budget.
+type Dependencies struct {
+	budget *Budget
+	mu     sync.Mutex
+	last   map[string]error
+}
+
+// DefaultDependencies is the tracker used by Call.
+var DefaultDependencies = NewDependencies(nil)
+
+func NewDependencies(budget *Budget) *Dependencies {
+	return &Dependencies{budget: budget, last: make(map[string]error)}
+}
+
+// Call runs fn against DefaultDependencies.
+func Call(ctx context.Context, dep string, fn func(ctx context.Context) error) error {
+	return DefaultDependencies.Call(ctx, dep, fn)
+}
+
+// Call times fn and returns its failure as *Error: foreign errors are wrapped with their
+// Classify code, and every failure gets dep appended to its chain and the dep and latency
+// fields. The outcome is reported to the MetricsRecorder under dep and recorded for
+// Health and the budget, except for failures caused by ctx being canceled, which say
+// nothing about the dependency.
+func (d *Dependencies) Call(ctx context.Context, dep string, fn func(ctx context.Context) error) error {
+	start := Now()
+	err := fn(ctx)
+	latency := Since(start)
+
+	var e *Error
+	code := CodeOK
+	if !isNil(err) {
+		code = Classify(err)
+		e = wrapOp(ctx, dep, code, err, latency).WithField(FieldDependency, dep)
+	}
+	if r := cfg().MetricsRecorder; r != nil {
+		r.RecordOperation(ctx, dep, code, latency)
+	}
+	if e != nil && ctx.Err() == context.Canceled && errors.Is(err, context.Canceled) {
+		return e
+	}
+
+	var failure error
+	if e != nil && e.System {
+		failure = e
+	}
+	d.mu.Lock()
+	d.last[dep] = failure
+	d.mu.Unlock()
+	if d.budget != nil {
+		d.budget.Record(failure)
+	}
+	if e == nil {
+		return nil
+	}
+	return e
+}
+
+// Health reports the last outcome of every dependency with CheckHealth. Business errors
+// count as healthy responses.
+func (d *Dependencies) Health() HealthReport {
+	d.mu.Lock()
+	deps := make(map[string]error, len(d.last))
+	for dep, err := range d.last {
+		deps[dep] = err
+	}
+	d.mu.Unlock()
+	return CheckHealth(deps, d.budget)
+}
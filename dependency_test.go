@@ -0,0 +1,91 @@
+package baseError
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"syscall"
+	"testing"
+	"time"
+)
+
+func TestClassify(t *testing.T) {
+	prev := CurrentConfig()
+	defer Configure(prev)
+	AddClassifier(func(err error) string {
+		if err.Error() == "no rows" {
+			return "DB_NOT_FOUND"
+		}
+		return ""
+	})
+
+	cases := map[error]string{
+		New("MINE", ""):                       "MINE",
+		errors.New("no rows"):                 "DB_NOT_FOUND",
+		syscall.ECONNREFUSED:                  CodeNetRefused,
+		context.DeadlineExceeded:              CodeDeadlineExceeded,
+		fmt.Errorf("x: %w", context.Canceled): CodeCanceled,
+		errors.New("boom"):                    "UNKNOWN",
+	}
+	for err, want := range cases {
+		if got := Classify(err); got != want {
+			t.Errorf("Classify(%v) = %q, want %q", err, got, want)
+		}
+	}
+}
+
+func TestCall(t *testing.T) {
+	prev := CurrentConfig()
+	defer Configure(prev)
+	var recorded []string
+	SetMetricsRecorder(MetricsRecorderFunc(func(_ context.Context, op string, code string, _ time.Duration) {
+		recorded = append(recorded, op+"="+code)
+	}))
+
+	d := NewDependencies(NewBudget(0.5, time.Minute))
+	ctx := context.Background()
+	if err := d.Call(ctx, "db", func(context.Context) error { return nil }); err != nil {
+		t.Fatal(err)
+	}
+	err := d.Call(ctx, "cache", func(context.Context) error { return syscall.ECONNREFUSED })
+	e, _ := err.(*Error)
+	if e == nil || e.Code != CodeNetRefused || !e.System || e.Chain != "cache" || e.Fields()[FieldDependency] != "cache" {
+		t.Fatalf("err = %#v", err)
+	}
+	if _, ok := e.Fields()[FieldLatency]; !ok {
+		t.Error("latency not stamped")
+	}
+	if err := d.Call(ctx, "db", func(context.Context) error { return New("NOT_FOUND", "") }); CodeOf(err) != "NOT_FOUND" {
+		t.Errorf("err = %v", err)
+	}
+
+	r := d.Health()
+	if r.Status != HealthDegraded || r.Dependencies["cache"] == nil || r.Dependencies["db"] != nil {
+		t.Errorf("report = %+v", r)
+	}
+	if got := d.budget.Remaining(); got <= 0 || got >= 1 {
+		t.Errorf("remaining = %v", got)
+	}
+	if fmt.Sprint(recorded) != "[db=OK cache=NET_CONN_REFUSED db=NOT_FOUND]" {
+		t.Errorf("recorded = %v", recorded)
+	}
+
+	canceled, cancel := context.WithCancel(ctx)
+	cancel()
+	err = d.Call(canceled, "search", func(ctx context.Context) error { return ctx.Err() })
+	if CodeOf(err) != CodeCanceled {
+		t.Errorf("err = %v", err)
+	}
+	if _, ok := d.Health().Dependencies["search"]; ok {
+		t.Error("cancellation recorded against the dependency")
+	}
+}
+
+func TestCallLeavesSentinel(t *testing.T) {
+	sentinel := New("DEP_SENTINEL", "")
+	d := NewDependencies(nil)
+	d.Call(context.Background(), "db", func(context.Context) error { return sentinel })
+	if sentinel.Chain != "" || len(sentinel.Fields()) != 0 {
+		t.Errorf("sentinel mutated: %+v", sentinel)
+	}
+}
@@ -0,0 +1,56 @@
+package baseError
+
+// AutoDepth passed as the depth of NewStack, SystemStack, WrapStack and their factories
+// picks the depth registered for the error's severity with SetStackDepth, or
+// Config.StackDepth if there is none.
+const AutoDepth = -1
+
+// SetStackDepth sets the number of frames captured for errors of severity created with
+// AutoDepth, e.g. 4 for SeverityWarning and 32 for SeverityCritical. The severity is the
+// one registered for the code, else SeverityError for System errors and SeverityWarning
+// otherwise. A depth of zero removes the entry.
+func (r *Registry) SetStackDepth(severity Severity, depth int) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if depth <= 0 {
+		delete(r.depths, severity)
+		return
+	}
+	if r.depths == nil {
+		r.depths = make(map[Severity]int)
+	}
+	r.depths[severity] = depth
+}
+
+// StackDepth returns the depth registered for the severity of code, or 0.
+func (r *Registry) StackDepth(code string, system bool) int {
+	severity := SeverityWarning
+	if system {
+		severity = SeverityError
+	}
+	if info, ok := r.Lookup(code); ok && info.Severity != 0 {
+		severity = info.Severity
+	}
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return r.depths[severity]
+}
+
+func RegisterStackDepth(severity Severity, depth int) {
+	DefaultRegistry.SetStackDepth(severity, depth)
+}
+
+// stackDepth resolves the depth argument of the stack constructors: 0 means one frame and
+// AutoDepth the registered policy.
+func stackDepth(r *Registry, c *Config, code string, system bool, depth int) int {
+	switch {
+	case depth > 0:
+		return depth
+	case depth == 0:
+		return 1
+	}
+	if d := r.StackDepth(code, system); d > 0 {
+		return d
+	}
+	return c.StackDepth
+}
@@ -0,0 +1,39 @@
+package baseError
+
+import "testing"
+
+func TestAutoDepth(t *testing.T) {
+	s := NewScope("depth")
+	r := s.Registry()
+	r.SetStackDepth(SeverityWarning, 1)
+	r.SetStackDepth(SeverityCritical, 12)
+	r.SetSeverity("DEPTH_LEDGER_CORRUPT", SeverityCritical)
+
+	var deep func(n int) *Error
+	deep = func(n int) *Error {
+		if n == 0 {
+			return s.SystemStack("DEPTH_LEDGER_CORRUPT", "corrupt", AutoDepth)
+		}
+		return deep(n - 1)
+	}
+	if n := len(*deep(16).stack); n != 12 {
+		t.Errorf("critical depth = %d", n)
+	}
+	if n := len(*s.NewStack("DEPTH_NOT_FOUND", "", AutoDepth).stack); n != 1 {
+		t.Errorf("warning depth = %d", n)
+	}
+	if n := len(*s.NewStack("DEPTH_NOT_FOUND", "", 0).stack); n != 1 {
+		t.Errorf("zero depth = %d", n)
+	}
+
+	c := s.CurrentConfig()
+	c.StackDepth = 2
+	s.Configure(c)
+	if n := len(*s.WrapStack("DEPTH_IO", New("A", ""), AutoDepth).stack); n != 2 {
+		t.Errorf("fallback depth = %d", n)
+	}
+	r.SetStackDepth(SeverityWarning, 0)
+	if n := len(*s.FactoryStack(AutoDepth, "DEPTH_NOT_FOUND")().stack); n != 2 {
+		t.Errorf("removed policy depth = %d", n)
+	}
+}
@@ -0,0 +1,66 @@
+package baseError
+
+import (
+	"fmt"
+	"io"
+	"sort"
+)
+
+// WithSafeDetails attaches details that may be shown to clients. They are serialized by
+// MarshalJSON under "details".
+func (b *Error) WithSafeDetails(details ...string) *Error {
+	b.safeDetails = append(b.safeDetails, details...)
+	return b
+}
+
+// WithDebugDetails attaches details meant for logs and reports only. No client-facing
+// renderer emits them; they appear in %+v output.
+func (b *Error) WithDebugDetails(details ...string) *Error {
+	b.debugDetails = append(b.debugDetails, details...)
+	return b
+}
+
+// SafeDetails also satisfies the cockroachdb/errors SafeDetailer interface.
+func (b *Error) SafeDetails() []string {
+	if b == nil {
+		return nil
+	}
+	return append([]string(nil), b.safeDetails...)
+}
+
+func (b *Error) DebugDetails() []string {
+	if b == nil {
+		return nil
+	}
+	return append([]string(nil), b.debugDetails...)
+}
+
+func (b *Error) formatFields(w io.Writer) {
+	if len(b.fields) == 0 {
+		return
+	}
+	keys := make([]string, 0, len(b.fields))
+	for k := range b.fields {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	io.WriteString(w, "\n---fields---")
+	for _, k := range keys {
+		fmt.Fprintf(w, "\n%s=%v", k, fieldValue(b.fields[k]))
+	}
+}
+
+func (b *Error) formatDetails(w io.Writer) {
+	if len(b.safeDetails) > 0 {
+		io.WriteString(w, "\n---details---")
+		for _, d := range b.safeDetails {
+			fmt.Fprintf(w, "\n%s", d)
+		}
+	}
+	if len(b.debugDetails) > 0 {
+		io.WriteString(w, "\n---debug---")
+		for _, d := range b.debugDetails {
+			fmt.Fprintf(w, "\n%s", d)
+		}
+	}
+}
@@ -0,0 +1,27 @@
+package baseError
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func TestDetails(t *testing.T) {
+	err := New("QUOTA_EXCEEDED", "quota exceeded").
+		WithSafeDetails("limit is 100 requests per minute").
+		WithDebugDetails("tenant=42 bucket=api:42")
+
+	buf, _ := json.Marshal(err)
+	if string(buf) != `{"code":"QUOTA_EXCEEDED","msg":"quota exceeded","details":["limit is 100 requests per minute"]}` {
+		t.Errorf("json = %s", buf)
+	}
+
+	out := fmt.Sprintf("%+v", err)
+	if !strings.Contains(out, "limit is 100") || !strings.Contains(out, "tenant=42") {
+		t.Errorf("%%+v = %s", out)
+	}
+	if s := fmt.Sprint(err); strings.Contains(s, "tenant") {
+		t.Errorf("%%v leaked debug details: %s", s)
+	}
+}
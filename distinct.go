@@ -0,0 +1,123 @@
+package baseError
+
+import (
+	"fmt"
+	"hash/fnv"
+	"math"
+	"math/bits"
+	"sort"
+	"sync"
+)
+
+const (
+	hllPrecision = 12
+	hllRegisters = 1 << hllPrecision
+)
+
+// DistinctCounter estimates, per code, how many distinct values of one field (a user or
+// tenant ID) were seen, using a 4 KiB HyperLogLog sketch per code with about 1.6%
+// standard error. The values themselves are never stored. Enable it with
+// AddHook(c.Hook()) and Reset it at the end of each reporting period. The field is
+// only set by NewDistinctCounter; a zero DistinctCounter counts the empty field name.
+type DistinctCounter struct {
+	field    string
+	mu       sync.Mutex
+	sketches map[string]*[hllRegisters]uint8
+}
+
+func NewDistinctCounter(field string) *DistinctCounter {
+	return &DistinctCounter{field: field, sketches: make(map[string]*[hllRegisters]uint8)}
+}
+
+// Add records the field value of the first *Error in err's chain; errors without it are
+// ignored.
+func (c *DistinctCounter) Add(err error) {
+	e := errorOf(err)
+	if e == nil {
+		return
+	}
+	v, ok := e.fields[c.field]
+	if !ok {
+		return
+	}
+	h := fnv.New64a()
+	fmt.Fprint(h, fieldValue(v))
+	x := mix64(h.Sum64())
+	idx := x >> (64 - hllPrecision)
+	rank := uint8(bits.LeadingZeros64(x<<hllPrecision|1<<(hllPrecision-1)) + 1)
+
+	code := CodeOf(err)
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.sketches == nil {
+		c.sketches = make(map[string]*[hllRegisters]uint8)
+	}
+	s := c.sketches[code]
+	if s == nil {
+		s = new([hllRegisters]uint8)
+		c.sketches[code] = s
+	}
+	if rank > s[idx] {
+		s[idx] = rank
+	}
+}
+
+func (c *DistinctCounter) Hook() Hook {
+	return c.Add
+}
+
+// Count returns the estimated number of distinct values seen with code.
+func (c *DistinctCounter) Count(code string) uint64 {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	s := c.sketches[code]
+	if s == nil {
+		return 0
+	}
+	var sum float64
+	zeros := 0
+	for _, r := range s {
+		sum += math.Ldexp(1, -int(r))
+		if r == 0 {
+			zeros++
+		}
+	}
+	m := float64(hllRegisters)
+	estimate := 0.7213 / (1 + 1.079/m) * m * m / sum
+	if estimate <= 2.5*m && zeros > 0 {
+		estimate = m * math.Log(m/float64(zeros))
+	}
+	return uint64(estimate + 0.5)
+}
+
+// Counts returns the estimate of every code seen.
+func (c *DistinctCounter) Counts() map[string]uint64 {
+	c.mu.Lock()
+	codes := make([]string, 0, len(c.sketches))
+	for code := range c.sketches {
+		codes = append(codes, code)
+	}
+	c.mu.Unlock()
+	sort.Strings(codes)
+	out := make(map[string]uint64, len(codes))
+	for _, code := range codes {
+		out[code] = c.Count(code)
+	}
+	return out
+}
+
+func (c *DistinctCounter) Reset() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.sketches = make(map[string]*[hllRegisters]uint8)
+}
+
+// mix64 is the splitmix64 finalizer; FNV alone spreads short keys poorly across registers.
+func mix64(x uint64) uint64 {
+	x ^= x >> 30
+	x *= 0xbf58476d1ce4e5b9
+	x ^= x >> 27
+	x *= 0x94d049bb133111eb
+	x ^= x >> 31
+	return x
+}
@@ -0,0 +1,40 @@
+package baseError
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestDistinctCounter(t *testing.T) {
+	c := NewDistinctCounter("user_id")
+	declined := Factory("PAYMENT_DECLINED")
+	for i := 0; i < 20000; i++ {
+		c.Add(declined().WithField("user_id", fmt.Sprintf("u-%d", i%5000)))
+	}
+	for i := 0; i < 10; i++ {
+		c.Add(New("SMALL", "").WithField("user_id", i))
+	}
+	c.Add(New("NO_USER", ""))
+
+	if n := c.Count("PAYMENT_DECLINED"); n < 4750 || n > 5250 {
+		t.Errorf("estimate = %d, want ~5000", n)
+	}
+	if n := c.Count("SMALL"); n != 10 {
+		t.Errorf("small estimate = %d", n)
+	}
+	if counts := c.Counts(); len(counts) != 2 || counts["NO_USER"] != 0 {
+		t.Errorf("counts = %v", counts)
+	}
+	c.Reset()
+	if c.Count("SMALL") != 0 {
+		t.Error("reset kept sketches")
+	}
+}
+
+func TestDistinctCounterZeroValue(t *testing.T) {
+	var c DistinctCounter
+	c.Add(New("ZERO", "").WithField("", "a"))
+	if n := c.Count("ZERO"); n != 1 {
+		t.Errorf("estimate = %d", n)
+	}
+}
@@ -0,0 +1,43 @@
+// Package echox renders baseError values from echo handlers.
+package echox
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+
+	baseError "github.com/go-tron/base-error"
+	"github.com/go-tron/base-error/httpx"
+	"github.com/labstack/echo/v4"
+)
+
+var _ echo.HTTPErrorHandler = ErrorHandler
+
+// ErrorHandler is an echo.HTTPErrorHandler writing errors with httpx.WriteError, so the
+// status comes from the registry and System errors are sanitized. Install it with
+// e.HTTPErrorHandler = echox.ErrorHandler. An *echo.HTTPError without a *Error inside,
+// such as echo's own 404 and 405, keeps its status and gets a code named after it.
+func ErrorHandler(err error, c echo.Context) {
+	if c.Response().Committed {
+		return
+	}
+	var he *echo.HTTPError
+	var e *baseError.Error
+	if errors.As(err, &he) && !errors.As(err, &e) {
+		e = baseError.New(httpx.CodeForStatus(he.Code), fmt.Sprint(he.Message))
+		if he.Code >= http.StatusInternalServerError {
+			e.WithSystem()
+		}
+		if c.Request().Method == http.MethodHead {
+			c.NoContent(he.Code)
+			return
+		}
+		c.JSON(he.Code, baseError.Sanitize(e))
+		return
+	}
+	if c.Request().Method == http.MethodHead {
+		c.NoContent(httpx.Status(err))
+		return
+	}
+	httpx.WriteError(c.Response(), err)
+}
@@ -0,0 +1,47 @@
+package echox
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	baseError "github.com/go-tron/base-error"
+	"github.com/labstack/echo/v4"
+)
+
+func TestErrorHandler(t *testing.T) {
+	baseError.RegisterHTTPStatus("ECHOX_ORDER_NOT_FOUND", http.StatusNotFound)
+	e := echo.New()
+	e.HTTPErrorHandler = ErrorHandler
+	orders := func(c echo.Context) error {
+		return baseError.New("ECHOX_ORDER_NOT_FOUND", "order not found")
+	}
+	e.GET("/orders", orders)
+	e.HEAD("/orders", orders)
+	e.GET("/db", func(c echo.Context) error {
+		return errors.New("password=hunter2")
+	})
+
+	for _, tc := range []struct {
+		method, path, body string
+		status             int
+	}{
+		{http.MethodGet, "/orders", `{"code":"ECHOX_ORDER_NOT_FOUND","msg":"order not found"}`, http.StatusNotFound},
+		{http.MethodGet, "/nowhere", `{"code":"NOT_FOUND","msg":"Not Found"}`, http.StatusNotFound},
+		{http.MethodHead, "/orders", "", http.StatusNotFound},
+	} {
+		w := httptest.NewRecorder()
+		e.ServeHTTP(w, httptest.NewRequest(tc.method, tc.path, nil))
+		if w.Code != tc.status || strings.TrimSpace(w.Body.String()) != tc.body {
+			t.Errorf("%s %s: %d %s", tc.method, tc.path, w.Code, w.Body)
+		}
+	}
+
+	w := httptest.NewRecorder()
+	e.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/db", nil))
+	if w.Code != http.StatusInternalServerError || strings.Contains(w.Body.String(), "hunter2") {
+		t.Errorf("/db: %d %s", w.Code, w.Body)
+	}
+}
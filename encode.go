@@ -0,0 +1,112 @@
+package baseError
+
+import (
+	"encoding/json"
+	"fmt"
+	"runtime"
+
+	"github.com/pkg/errors"
+)
+
+// EncodeOptions selects what EncodeJSON emits beyond code, message, system flag, chain,
+// fields, safe details and origin.
+type EncodeOptions struct {
+	// Stack includes resolved stack frames.
+	Stack bool
+	// Causes includes the cause chain, each cause encoded the same way.
+	Causes bool
+	// Debug includes debug details.
+	Debug bool
+}
+
+type frameJSON struct {
+	Function string `json:"function"`
+	File     string `json:"file"`
+	Line     int    `json:"line"`
+}
+
+type fullJSON struct {
+	Code    string                 `json:"code,omitempty"`
+	Type    string                 `json:"type,omitempty"`
+	Msg     string                 `json:"msg"`
+	System  bool                   `json:"system,omitempty"`
+	Chain   string                 `json:"chain,omitempty"`
+	Fields  map[string]interface{} `json:"fields,omitempty"`
+	Details []string               `json:"details,omitempty"`
+	Debug   []string               `json:"debug,omitempty"`
+	Origin  *Origin                `json:"origin,omitempty"`
+	Stack   []frameJSON            `json:"stack,omitempty"`
+	Causes  []fullJSON             `json:"causes,omitempty"`
+}
+
+// EncodeJSON is the log pipeline counterpart of MarshalJSON: it encodes everything err
+// carries, not only what clients may see. Foreign errors in the chain are encoded with
+// their type and message.
+func EncodeJSON(err error, opts EncodeOptions) ([]byte, error) {
+	if isNil(err) {
+		return []byte("null"), nil
+	}
+	return json.Marshal(encodeFull(err, opts, 0))
+}
+
+// MarshalFull is EncodeJSON with every option on.
+func (b *Error) MarshalFull() ([]byte, error) {
+	return EncodeJSON(b, EncodeOptions{Stack: true, Causes: true, Debug: true})
+}
+
+func encodeFull(err error, opts EncodeOptions, depth int) fullJSON {
+	var out fullJSON
+	if e, ok := err.(*Error); ok {
+		out = fullJSON{
+			Code:    e.Code,
+			Msg:     e.Msg,
+			System:  e.System,
+			Chain:   e.Chain,
+			Fields:  e.jsonFields(),
+			Details: e.safeDetails,
+		}
+		if ref, ok := e.fields[FieldReference]; ok {
+			if out.Fields == nil {
+				out.Fields = make(map[string]interface{})
+			}
+			out.Fields[FieldReference] = ref
+		}
+		if opts.Debug {
+			out.Debug = e.debugDetails
+		}
+		if !e.origin.IsZero() {
+			out.Origin = &e.origin
+		}
+	} else {
+		out = fullJSON{Type: fmt.Sprintf("%T", err), Msg: err.Error()}
+	}
+	if opts.Stack {
+		out.Stack = framesJSON(err)
+	}
+	if opts.Causes && depth < maxCauseDepth {
+		for _, c := range causes(err) {
+			out.Causes = append(out.Causes, encodeFull(c, opts, depth+1))
+		}
+	}
+	return out
+}
+
+// framesJSON encodes the stack err itself carries, not one found deeper in its chain.
+func framesJSON(err error) []frameJSON {
+	var frames []runtime.Frame
+	switch x := err.(type) {
+	case *Error:
+		frames = x.stack.Frames()
+	case *withStack:
+		frames = x.stack.Frames()
+	default:
+		if _, ok := err.(interface{ StackTrace() errors.StackTrace }); ok {
+			frames = StackFrames(err)
+		}
+	}
+	out := make([]frameJSON, len(frames))
+	for i, f := range frames {
+		out[i] = frameJSON{Function: f.Function, File: f.File, Line: f.Line}
+	}
+	return out
+}
@@ -0,0 +1,48 @@
+package baseError
+
+import (
+	"encoding/json"
+	"io"
+	"strings"
+	"testing"
+)
+
+func TestEncodeJSON(t *testing.T) {
+	inner := NewStack("DB_TIMEOUT", "query timed out", 4).WithSystem().WithDebugDetails("shard=3")
+	err := Wrap("LOAD_FAILED", inner).WithChain("handler", "service").WithField("user", 7)
+
+	buf, e := err.MarshalFull()
+	if e != nil {
+		t.Fatal(e)
+	}
+	var out struct {
+		Code   string
+		System bool
+		Chain  string
+		Fields map[string]interface{}
+		Causes []struct {
+			Code  string
+			Debug []string
+			Stack []struct {
+				Function string
+				Line     int
+			}
+		}
+	}
+	json.Unmarshal(buf, &out)
+	if out.Code != "LOAD_FAILED" || !out.System || out.Chain != "handler<-service" || out.Fields["user"] != float64(7) {
+		t.Errorf("json = %s", buf)
+	}
+	if len(out.Causes) != 1 || out.Causes[0].Code != "DB_TIMEOUT" || out.Causes[0].Debug[0] != "shard=3" || len(out.Causes[0].Stack) == 0 || out.Causes[0].Stack[0].Line == 0 {
+		t.Errorf("json = %s", buf)
+	}
+
+	buf, _ = EncodeJSON(Wrap("IO", io.EOF), EncodeOptions{})
+	if want := `{"code":"IO","msg":"EOF","system":true}`; string(buf) != want {
+		t.Errorf("json = %s", buf)
+	}
+	buf, _ = EncodeJSON(Wrap("IO", io.EOF), EncodeOptions{Causes: true})
+	if !strings.Contains(string(buf), `"causes":[{"type":"*errors.errorString","msg":"EOF"}]`) {
+		t.Errorf("json = %s", buf)
+	}
+}
@@ -0,0 +1,90 @@
+package baseError
+
+import "reflect"
+
+// volatileFields differ between occurrences of the same failure and are ignored by Equal.
+var volatileFields = []string{FieldReference, FieldLatency, FieldDuration, FieldFirstOccurrence, FieldTimeoutRemaining, FieldRetryHistory, FieldAttempt, FieldEscalated, FieldCachedAt, FieldObservedAt}
+
+type equalConfig struct {
+	ignore   map[string]bool
+	messages bool
+	stacks   bool
+}
+
+type EqualOption func(*equalConfig)
+
+// IgnoreFields ignores more fields in addition to the volatile ones.
+func IgnoreFields(keys ...string) EqualOption {
+	return func(c *equalConfig) {
+		for _, k := range keys {
+			c.ignore[k] = true
+		}
+	}
+}
+
+// CompareMessages compares the internal messages of System errors too, not only their
+// public ones.
+func CompareMessages() EqualOption {
+	return func(c *equalConfig) { c.messages = true }
+}
+
+// CompareStacks requires the stacks to be equal as well.
+func CompareStacks() EqualOption {
+	return func(c *equalConfig) { c.stacks = true }
+}
+
+// Equal reports whether a and b describe the same failure: same code, same System flag,
+// same public message and same fields. References, latencies, timestamps, attempts and
+// stacks are ignored unless opts say otherwise. Foreign errors are equal if their
+// messages are.
+func Equal(a, b error, opts ...EqualOption) bool {
+	if isNil(a) || isNil(b) {
+		return isNil(a) && isNil(b)
+	}
+	c := &equalConfig{ignore: make(map[string]bool)}
+	for _, k := range volatileFields {
+		c.ignore[k] = true
+	}
+	for _, opt := range opts {
+		opt(c)
+	}
+
+	ea, eb := errorOf(a), errorOf(b)
+	if ea == nil || eb == nil {
+		return ea == nil && eb == nil && a.Error() == b.Error()
+	}
+	if ea.Code != eb.Code || ea.System != eb.System || publicMsg(ea) != publicMsg(eb) {
+		return false
+	}
+	if c.messages && ea.Msg != eb.Msg {
+		return false
+	}
+	if c.stacks && !StackEqual(ea, eb, 0) {
+		return false
+	}
+	return equalFields(ea.fields, eb.fields, c.ignore) && equalFields(eb.fields, ea.fields, c.ignore)
+}
+
+func equalFields(a, b map[string]interface{}, ignore map[string]bool) bool {
+	for k, v := range a {
+		if ignore[k] {
+			continue
+		}
+		w, ok := b[k]
+		if !ok || !reflect.DeepEqual(v, w) {
+			return false
+		}
+	}
+	return true
+}
+
+// publicMsg is the message Sanitize would expose for e.
+func publicMsg(e *Error) string {
+	if !e.System {
+		return e.Msg
+	}
+	if info, ok := e.registry().Lookup(e.Code); ok && info.PublicCode != "" {
+		return info.PublicMsg
+	}
+	return e.cfg().SanitizedMsg
+}
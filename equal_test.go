@@ -0,0 +1,37 @@
+package baseError
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestEqual(t *testing.T) {
+	poll := func(latency time.Duration) *Error {
+		return WrapStack("UPSTREAM_DOWN", errors.New("dial tcp 10.0.0.7: refused"), 8).
+			WithField("upstream", "billing").
+			WithField(FieldLatency, latency)
+	}
+	a, b := poll(time.Millisecond), poll(time.Second)
+	Sanitize(a)
+	if !Equal(a, b) {
+		t.Error("same failure not equal")
+	}
+	if Equal(a, poll(0).WithField("upstream", "ledger")) {
+		t.Error("different fields equal")
+	}
+	if !Equal(a, b.WithField("shard", 2), IgnoreFields("shard")) {
+		t.Error("ignored field compared")
+	}
+
+	x, y := System("UPSTREAM_DOWN", "refused by 10.0.0.7"), System("UPSTREAM_DOWN", "refused by 10.0.0.8")
+	if !Equal(x, y) || Equal(x, y, CompareMessages()) {
+		t.Error("System messages should only matter with CompareMessages")
+	}
+	if Equal(New("A", "one"), New("A", "two")) || Equal(New("A", ""), System("A", "")) {
+		t.Error("public message or kind ignored")
+	}
+	if !Equal(errors.New("x"), errors.New("x")) || Equal(errors.New("x"), New("X", "x")) || !Equal(nil, nil) || Equal(nil, a) {
+		t.Error("foreign and nil handling")
+	}
+}
@@ -0,0 +1,28 @@
+// Package erisx absorbs errors created with github.com/rotisserie/eris.
+package erisx
+
+import (
+	"fmt"
+
+	baseError "github.com/go-tron/base-error"
+	"github.com/rotisserie/eris"
+)
+
+// Absorb wraps err like baseError.Absorb and, since eris stacks are not exposed as
+// program counters, records the eris root stack as "function file:line" strings in the
+// foreign_stack field.
+func Absorb(code string, err error) *baseError.Error {
+	e := baseError.Absorb(code, err)
+	if e == nil {
+		return nil
+	}
+	up := eris.Unpack(err)
+	if len(up.ErrRoot.Stack) == 0 {
+		return e
+	}
+	frames := make([]string, len(up.ErrRoot.Stack))
+	for i, f := range up.ErrRoot.Stack {
+		frames[i] = fmt.Sprintf("%s %s:%d", f.Name, f.File, f.Line)
+	}
+	return e.WithField(baseError.FieldForeignStack, frames)
+}
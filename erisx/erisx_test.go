@@ -0,0 +1,20 @@
+package erisx
+
+import (
+	"strings"
+	"testing"
+
+	baseError "github.com/go-tron/base-error"
+	"github.com/rotisserie/eris"
+)
+
+func TestAbsorb(t *testing.T) {
+	err := Absorb("UPSTREAM", eris.Wrap(eris.New("timeout"), "call billing"))
+	if err.Code != "UPSTREAM" || err.Msg != "call billing: timeout" {
+		t.Fatalf("got %v", err)
+	}
+	frames, _ := err.Fields()[baseError.FieldForeignStack].([]string)
+	if len(frames) == 0 || !strings.Contains(strings.Join(frames, "\n"), "TestAbsorb") {
+		t.Errorf("frames = %v", frames)
+	}
+}
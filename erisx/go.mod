@@ -0,0 +1,15 @@
+module github.com/go-tron/base-error/erisx
+
+go 1.20
+
+require (
+	github.com/go-tron/base-error v0.0.0-00010101000000-000000000000
+	github.com/rotisserie/eris v0.5.4
+)
+
+require (
+	github.com/pkg/errors v0.9.1 // indirect
+	golang.org/x/text v0.14.0 // indirect
+)
+
+replace github.com/go-tron/base-error => ../
@@ -0,0 +1,17 @@
+package errtest
+
+import (
+	"testing"
+
+	baseError "github.com/go-tron/base-error"
+)
+
+// RequireBoundary fails t if any of errs leaks through b.
+func RequireBoundary(t testing.TB, b *baseError.Boundary, errs ...error) {
+	t.Helper()
+	for _, err := range errs {
+		if leak := b.Check(err); leak != nil {
+			t.Error(leak)
+		}
+	}
+}
@@ -0,0 +1,83 @@
+package errtest
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+
+	baseError "github.com/go-tron/base-error"
+)
+
+// RunConformance checks that errors built by newErr, which must wrap the cause it is
+// given, keep the stdlib and fmt invariants *Error provides: errors.As finds the *Error,
+// errors.Is matches its code sentinel, the cause stays reachable, and %s, %v, %q and %+v
+// render consistently with Error().
+func RunConformance(t *testing.T, newErr func(cause error) error) {
+	t.Helper()
+	cause := errors.New("errtest: conformance cause")
+
+	t.Run("As", func(t *testing.T) {
+		var e *baseError.Error
+		if !errors.As(newErr(cause), &e) {
+			t.Fatal("errors.As does not find a *baseError.Error")
+		}
+		if e.Code == "" {
+			t.Error("*baseError.Error has an empty code")
+		}
+	})
+
+	t.Run("Is", func(t *testing.T) {
+		err := newErr(cause)
+		var e *baseError.Error
+		if !errors.As(err, &e) {
+			t.Skip("no *baseError.Error to take the code from")
+		}
+		if !errors.Is(err, baseError.CodeError(e.Code)) {
+			t.Errorf("errors.Is(err, CodeError(%q)) = false", e.Code)
+		}
+		if errors.Is(err, baseError.CodeError(e.Code+"_OTHER")) {
+			t.Error("errors.Is matches a foreign code")
+		}
+	})
+
+	t.Run("Cause", func(t *testing.T) {
+		if !reaches(newErr(cause), cause) {
+			t.Error("cause is not reachable through Unwrap or Cause")
+		}
+	})
+
+	t.Run("Format", func(t *testing.T) {
+		err := newErr(cause)
+		msg := err.Error()
+		if got := fmt.Sprintf("%s", err); got != msg {
+			t.Errorf("%%s = %q, Error() = %q", got, msg)
+		}
+		if got := fmt.Sprintf("%v", err); got != msg {
+			t.Errorf("%%v = %q, Error() = %q", got, msg)
+		}
+		if got, want := fmt.Sprintf("%q", err), fmt.Sprintf("%q", msg); got != want {
+			t.Errorf("%%q = %s, want %s", got, want)
+		}
+		if got := fmt.Sprintf("%+v", err); !strings.HasPrefix(got, msg) {
+			t.Errorf("%%+v = %q does not start with Error()", got)
+		}
+	})
+}
+
+func reaches(err, target error) bool {
+	for depth := 0; err != nil && depth < 32; depth++ {
+		if errors.Is(err, target) {
+			return true
+		}
+		switch e := err.(type) {
+		case interface{ Unwrap() error }:
+			err = e.Unwrap()
+		case interface{ Cause() error }:
+			err = e.Cause()
+		default:
+			return false
+		}
+	}
+	return false
+}
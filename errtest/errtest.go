@@ -0,0 +1,30 @@
+// Package errtest provides test helpers for code built on baseError.
+package errtest
+
+import (
+	"testing"
+
+	baseError "github.com/go-tron/base-error"
+)
+
+// Override calls fn with every *Error constructed with code until the test ends. Since it
+// only touches errors of that code, parallel tests overriding different codes don't
+// interfere with each other.
+func Override(t testing.TB, code string, fn func(e *baseError.Error)) {
+	t.Helper()
+	remove := baseError.AddCreationHook(func(e *baseError.Error) {
+		if e.Code == code {
+			fn(e)
+		}
+	})
+	t.Cleanup(remove)
+}
+
+// Strict enables baseError.SetStrict until the test ends, so constructor misuse panics.
+// It changes global configuration, so don't use it in parallel tests.
+func Strict(t testing.TB) {
+	t.Helper()
+	prev := baseError.CurrentConfig()
+	baseError.SetStrict(true)
+	t.Cleanup(func() { baseError.Configure(prev) })
+}
@@ -0,0 +1,116 @@
+package errtest
+
+import (
+	"errors"
+	"io"
+	"testing"
+
+	baseError "github.com/go-tron/base-error"
+)
+
+var notFound = baseError.FactoryStack(8, "ERRTEST_NOT_FOUND", "{} not found")
+
+// fakeTB records the failures reported to it instead of failing the running test.
+type fakeTB struct {
+	testing.TB
+	failed bool
+}
+
+func (f *fakeTB) Helper()                                   {}
+func (f *fakeTB) Error(args ...interface{})                 { f.failed = true }
+func (f *fakeTB) Errorf(format string, args ...interface{}) { f.failed = true }
+func (f *fakeTB) Failed() bool                              { return f.failed }
+
+func TestOverride(t *testing.T) {
+	t.Run("override", func(t *testing.T) {
+		Override(t, "ERRTEST_NOT_FOUND", func(e *baseError.Error) {
+			e.WithoutStack().WithField("fixed", true)
+		})
+		err := notFound("order")
+		if err.Stack() != nil || err.Fields()["fixed"] != true {
+			t.Errorf("override not applied: %+v", err)
+		}
+		if other := baseError.NewStack("OTHER", "", 4); other.Stack() == nil {
+			t.Error("override applied to another code")
+		}
+	})
+
+	if err := notFound("order"); err.Stack() == nil {
+		t.Error("override survived its test")
+	}
+}
+
+func TestRecorder(t *testing.T) {
+	var r Recorder
+	var m baseError.ErrorMaker = &r
+	m.New("A", "a")
+	if e := m.WrapStack("B", io.EOF, 4); !errors.Is(e, io.EOF) || e.Cause() != io.EOF || !e.System {
+		t.Errorf("wrapped = %+v", e)
+	}
+	m.Wrap("C", nil)
+	var nilErr *baseError.Error
+	if m.Wrap("D", nilErr) != nil {
+		t.Error("typed nil wrapped")
+	}
+	if got := r.Codes(); len(got) != 2 || got[0] != "A" || got[1] != "B" {
+		t.Errorf("codes = %v", got)
+	}
+	r.Reset()
+	if len(r.Codes()) != 0 {
+		t.Error("reset kept codes")
+	}
+}
+
+type validationError struct {
+	base  *baseError.Error
+	Field string
+}
+
+func (v *validationError) Error() string { return v.base.Error() }
+
+func (v *validationError) Unwrap() error { return v.base }
+
+func TestRunConformance(t *testing.T) {
+	RunConformance(t, func(cause error) error {
+		return baseError.WrapStack("ERRTEST_WRAP", cause, 4)
+	})
+	RunConformance(t, func(cause error) error {
+		return &validationError{base: baseError.Wrap("ERRTEST_INVALID", cause), Field: "email"}
+	})
+}
+
+func TestRequireTranslations(t *testing.T) {
+	r := baseError.NewRegistry()
+	r.SetTemplate("ERRTEST_GREETING", "hello {}")
+	r.SetMessage("ERRTEST_GREETING", "fr", "bonjour {}")
+	RequireTranslations(t, r, "fr")
+
+	probe := &fakeTB{}
+	RequireTranslations(probe, r, "fr", "es")
+	if !probe.Failed() {
+		t.Error("missing es translation not reported")
+	}
+}
+
+func TestStrict(t *testing.T) {
+	t.Run("enabled", func(t *testing.T) {
+		Strict(t)
+		if !baseError.CurrentConfig().Strict {
+			t.Error("strict mode not enabled")
+		}
+	})
+	if baseError.CurrentConfig().Strict {
+		t.Error("strict mode not restored")
+	}
+}
+
+func TestRequireBoundary(t *testing.T) {
+	b := baseError.NewBoundary("errtest", "ERRTEST_ALLOWED")
+	RequireBoundary(t, b, nil, baseError.New("ERRTEST_ALLOWED", ""))
+
+	probe := &fakeTB{}
+	RequireBoundary(probe, b, baseError.New("ERRTEST_INTERNAL", ""))
+	if !probe.Failed() {
+		t.Error("leaked code not reported")
+	}
+}
@@ -0,0 +1,19 @@
+package errtest
+
+import (
+	"testing"
+
+	baseError "github.com/go-tron/base-error"
+)
+
+// RequireTranslations fails t if any translatable code of r, or of DefaultRegistry when
+// r is nil, lacks a message for one of locales.
+func RequireTranslations(t testing.TB, r *baseError.Registry, locales ...string) {
+	t.Helper()
+	if r == nil {
+		r = baseError.DefaultRegistry
+	}
+	if report := r.Localization(locales...); !report.Complete() {
+		t.Errorf("incomplete translations:\n%s", report)
+	}
+}
@@ -0,0 +1,66 @@
+package errtest
+
+import (
+	"sync"
+
+	baseError "github.com/go-tron/base-error"
+)
+
+// Recorder is a baseError.ErrorMaker that records the code of every error it constructs,
+// so tests can assert on codes without comparing *Error internals. The errors it returns
+// carry no stack and do not run creation hooks; wrapped errors keep their cause, as with
+// baseError.Wrap.
+type Recorder struct {
+	mu    sync.Mutex
+	codes []string
+}
+
+var _ baseError.ErrorMaker = (*Recorder)(nil)
+
+// Codes returns the recorded codes in construction order.
+func (r *Recorder) Codes() []string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]string(nil), r.codes...)
+}
+
+// Reset forgets the recorded codes.
+func (r *Recorder) Reset() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.codes = nil
+}
+
+func (r *Recorder) record(e *baseError.Error) *baseError.Error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.codes = append(r.codes, e.Code)
+	return e
+}
+
+func (r *Recorder) New(code string, msg string) *baseError.Error {
+	return r.record(&baseError.Error{Code: code, Msg: msg})
+}
+
+func (r *Recorder) NewStack(code string, msg string, depth int) *baseError.Error {
+	return r.New(code, msg)
+}
+
+func (r *Recorder) System(code string, msg string) *baseError.Error {
+	return r.record(&baseError.Error{Code: code, Msg: msg, System: true})
+}
+
+func (r *Recorder) SystemStack(code string, msg string, depth int) *baseError.Error {
+	return r.System(code, msg)
+}
+
+func (r *Recorder) Wrap(code string, err error) *baseError.Error {
+	if e, ok := err.(*baseError.Error); err == nil || ok && e == nil {
+		return nil
+	}
+	return r.record((&baseError.Error{Code: code, Msg: err.Error(), System: true}).WithCause(err))
+}
+
+func (r *Recorder) WrapStack(code string, err error, depth int) *baseError.Error {
+	return r.Wrap(code, err)
+}
@@ -0,0 +1,95 @@
+package baseError
+
+import (
+	"sync"
+	"time"
+)
+
+const FieldEscalated = "escalated"
+
+// Threshold escalates errors to Severity once Count of them occurred within Window.
+type Threshold struct {
+	Count    int
+	Window   time.Duration
+	Severity Severity
+}
+
+// Escalator raises the severity of errors whose code occurs faster than configured, so a
+// trickle of NOT_FOUND stays Info while a flood becomes Critical. Install it with
+// AddHook(escalator.Hook()).
+type Escalator struct {
+	mu         sync.Mutex
+	thresholds map[string][]Threshold
+	events     map[string][]time.Time
+}
+
+func NewEscalator() *Escalator {
+	return &Escalator{thresholds: make(map[string][]Threshold), events: make(map[string][]time.Time)}
+}
+
+func (x *Escalator) SetThresholds(code string, thresholds ...Threshold) {
+	x.mu.Lock()
+	defer x.mu.Unlock()
+	x.thresholds[code] = thresholds
+}
+
+// Observe records err and returns the severity it should now have. When that is higher
+// than its current severity, a copy of err with the new severity, marked with the
+// escalated field, is reported so that hooks see it; err itself is left unchanged.
+func (x *Escalator) Observe(err error) Severity {
+	e := errorOf(err)
+	if e == nil {
+		return SeverityOf(err)
+	}
+	if _, ok := e.fields[FieldEscalated]; ok {
+		return SeverityOf(e)
+	}
+
+	observed := Now()
+	x.mu.Lock()
+	thresholds := x.thresholds[e.Code]
+	if len(thresholds) == 0 {
+		x.mu.Unlock()
+		return SeverityOf(e)
+	}
+	var window time.Duration
+	for _, t := range thresholds {
+		if t.Window > window {
+			window = t.Window
+		}
+	}
+	events := append(x.events[e.Code], observed)
+	for len(events) > 0 && observed.Sub(events[0]) > window {
+		events = events[1:]
+	}
+	x.events[e.Code] = events
+
+	severity := SeverityOf(e)
+	escalated := severity
+	for _, t := range thresholds {
+		if t.Severity <= escalated {
+			continue
+		}
+		n := 0
+		for _, at := range events {
+			if observed.Sub(at) <= t.Window {
+				n++
+			}
+		}
+		if n >= t.Count {
+			escalated = t.Severity
+		}
+	}
+	x.mu.Unlock()
+
+	if escalated > severity {
+		Report(e.Copy().WithSeverity(escalated).WithField(FieldEscalated, severity.String()))
+	}
+	return escalated
+}
+
+func (x *Escalator) Hook() Hook {
+	return func(err error) {
+		x.Observe(err)
+	}
+}
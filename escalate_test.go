@@ -0,0 +1,53 @@
+package baseError
+
+import (
+	"testing"
+	"time"
+)
+
+func TestEscalator(t *testing.T) {
+	prev := CurrentConfig()
+	defer Configure(prev)
+
+	x := NewEscalator()
+	x.SetThresholds("NOT_FOUND",
+		Threshold{Count: 3, Window: time.Minute, Severity: SeverityError},
+		Threshold{Count: 5, Window: time.Minute, Severity: SeverityCritical},
+	)
+
+	var got []Severity
+	for i := 0; i < 5; i++ {
+		got = append(got, x.Observe(New("NOT_FOUND", "").WithSeverity(SeverityInfo)))
+	}
+	want := []Severity{SeverityInfo, SeverityInfo, SeverityError, SeverityError, SeverityCritical}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Fatalf("got %v, want %v", got, want)
+		}
+	}
+
+	var refired int
+	AddHook(func(err error) {
+		if CodeOf(err) == "TEST_FLOOD" && SeverityOf(err) == SeverityCritical {
+			refired++
+		}
+	})
+	AddHook(x.Hook())
+	x.SetThresholds("TEST_FLOOD", Threshold{Count: 2, Window: time.Minute, Severity: SeverityCritical})
+	Report(New("TEST_FLOOD", ""))
+	Report(New("TEST_FLOOD", ""))
+	if refired != 1 {
+		t.Errorf("refired = %d", refired)
+	}
+
+	x.SetThresholds("TEST_SENTINEL", Threshold{Count: 2, Window: time.Minute, Severity: SeverityCritical})
+	sentinel := New("TEST_SENTINEL", "").WithSeverity(SeverityInfo)
+	for i := 0; i < 3; i++ {
+		if s := x.Observe(sentinel); i > 0 && s != SeverityCritical {
+			t.Errorf("occurrence %d: severity = %v", i, s)
+		}
+	}
+	if _, ok := sentinel.Fields()[FieldEscalated]; ok || SeverityOf(sentinel) != SeverityInfo {
+		t.Error("sentinel modified")
+	}
+}
@@ -0,0 +1,163 @@
+// Package fault injects errors and latency into dependency calls for load tests, driven by
+// a JSON plan:
+//
+//	{
+//	  "payments": {
+//	    "rate": 0.2,
+//	    "latency": "150ms",
+//	    "jitter": "50ms",
+//	    "faults": [
+//	      {"code": "NET_TIMEOUT", "weight": 3, "system": true},
+//	      {"code": "CARD_DECLINED", "weight": 1, "msg": "card declined"}
+//	    ]
+//	  }
+//	}
+//
+// Each call to a dependency fails with probability rate. A failing call first waits
+// latency plus up to jitter, then returns one of the faults picked by weight.
+package fault
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math/rand"
+	"sync"
+	"time"
+
+	baseError "github.com/go-tron/base-error"
+)
+
+// FieldInjected marks injected errors.
+const FieldInjected = "injected"
+
+// Duration is a time.Duration read from strings such as "150ms".
+type Duration time.Duration
+
+func (d *Duration) UnmarshalJSON(data []byte) error {
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		return err
+	}
+	v, err := time.ParseDuration(s)
+	*d = Duration(v)
+	return err
+}
+
+// Fault is one error a Plan can return.
+type Fault struct {
+	Code   string  `json:"code"`
+	Msg    string  `json:"msg"`
+	System bool    `json:"system"`
+	Weight float64 `json:"weight"`
+}
+
+// Plan describes the faults injected into one dependency.
+type Plan struct {
+	Rate    float64  `json:"rate"`
+	Latency Duration `json:"latency"`
+	Jitter  Duration `json:"jitter"`
+	Faults  []Fault  `json:"faults"`
+}
+
+// Injector holds the plans of every dependency. It is safe for concurrent use.
+type Injector struct {
+	mu    sync.RWMutex
+	plans map[string]Plan
+	rand  func() float64
+}
+
+func NewInjector() *Injector {
+	return &Injector{plans: make(map[string]Plan), rand: rand.Float64}
+}
+
+// Load reads a JSON plan file, mapping dependency names to plans.
+func Load(r io.Reader) (*Injector, error) {
+	var plans map[string]Plan
+	if err := json.NewDecoder(r).Decode(&plans); err != nil {
+		return nil, fmt.Errorf("fault: %w", err)
+	}
+	i := NewInjector()
+	for dep, p := range plans {
+		if err := i.SetPlan(dep, p); err != nil {
+			return nil, err
+		}
+	}
+	return i, nil
+}
+
+// SetPlan replaces the plan of dep. It fails if a fault has no code or a negative weight.
+func (i *Injector) SetPlan(dep string, p Plan) error {
+	for _, f := range p.Faults {
+		if f.Code == "" || f.Weight < 0 {
+			return fmt.Errorf("fault: invalid fault %+v for %s", f, dep)
+		}
+	}
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	i.plans[dep] = p
+	return nil
+}
+
+// Inject returns the fault drawn for a call to dep, after waiting its latency, or nil.
+// If ctx ends first, the context's error is returned.
+func (i *Injector) Inject(ctx context.Context, dep string) error {
+	i.mu.RLock()
+	p, ok := i.plans[dep]
+	i.mu.RUnlock()
+	if !ok || len(p.Faults) == 0 || i.rand() >= p.Rate {
+		return nil
+	}
+
+	wait := time.Duration(p.Latency)
+	if p.Jitter > 0 {
+		wait += time.Duration(i.rand() * float64(p.Jitter))
+	}
+	if wait > 0 {
+		t := time.NewTimer(wait)
+		defer t.Stop()
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-t.C:
+		}
+	}
+
+	f := pick(p.Faults, i.rand())
+	var e *baseError.Error
+	if f.System {
+		e = baseError.System(f.Code, f.Msg)
+	} else {
+		e = baseError.New(f.Code, f.Msg)
+	}
+	return e.WithField(FieldInjected, dep)
+}
+
+// Call runs fn unless Inject returns a fault for dep.
+func (i *Injector) Call(ctx context.Context, dep string, fn func(ctx context.Context) error) error {
+	if err := i.Inject(ctx, dep); err != nil {
+		return err
+	}
+	return fn(ctx)
+}
+
+// pick returns the fault selected by u in [0, 1) according to the weights. With no
+// positive weight every fault is equally likely.
+func pick(faults []Fault, u float64) Fault {
+	var total float64
+	for _, f := range faults {
+		total += f.Weight
+	}
+	if total == 0 {
+		return faults[int(u*float64(len(faults)))]
+	}
+	target := u * total
+	for _, f := range faults {
+		if target < f.Weight {
+			return f
+		}
+		target -= f.Weight
+	}
+	return faults[len(faults)-1]
+}
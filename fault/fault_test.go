@@ -0,0 +1,83 @@
+package fault
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	baseError "github.com/go-tron/base-error"
+)
+
+const plan = `{
+  "payments": {
+    "rate": 0.5,
+    "latency": "2ms",
+    "faults": [
+      {"code": "NET_TIMEOUT", "weight": 3, "system": true},
+      {"code": "CARD_DECLINED", "weight": 1, "msg": "card declined"}
+    ]
+  }
+}`
+
+func TestInjector(t *testing.T) {
+	i, err := Load(strings.NewReader(plan))
+	if err != nil {
+		t.Fatal(err)
+	}
+	draws := []float64{0.1, 0.9}
+	i.rand = func() float64 {
+		u := draws[0]
+		draws = draws[1:]
+		return u
+	}
+
+	start := time.Now()
+	err = i.Inject(context.Background(), "payments")
+	var e *baseError.Error
+	if e, _ = err.(*baseError.Error); e == nil || e.Code != "CARD_DECLINED" || e.System || e.Fields()[FieldInjected] != "payments" {
+		t.Fatalf("err = %v", err)
+	}
+	if time.Since(start) < 2*time.Millisecond {
+		t.Error("latency not injected")
+	}
+
+	draws = []float64{0.7}
+	called := false
+	if err := i.Call(context.Background(), "payments", func(context.Context) error { called = true; return nil }); err != nil || !called {
+		t.Errorf("err = %v called = %v", err, called)
+	}
+	if i.Inject(context.Background(), "search") != nil {
+		t.Error("fault injected without a plan")
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	draws = []float64{0}
+	if err := i.Inject(ctx, "payments"); err != context.Canceled {
+		t.Errorf("canceled err = %v", err)
+	}
+}
+
+func TestPick(t *testing.T) {
+	faults := []Fault{{Code: "A", Weight: 3}, {Code: "B", Weight: 1}}
+	counts := map[string]int{}
+	for n := 0; n < 100; n++ {
+		counts[pick(faults, float64(n)/100).Code]++
+	}
+	if counts["A"] != 75 || counts["B"] != 25 {
+		t.Errorf("counts = %v", counts)
+	}
+	if pick([]Fault{{Code: "A"}, {Code: "B"}}, 0.6).Code != "B" {
+		t.Error("zero weights not uniform")
+	}
+}
+
+func TestLoadInvalid(t *testing.T) {
+	if _, err := Load(strings.NewReader(`{"x":{"faults":[{"weight":1}]}}`)); err == nil {
+		t.Error("fault without code accepted")
+	}
+	if _, err := Load(strings.NewReader(`{"x":{"latency":"soon"}}`)); err == nil {
+		t.Error("bad duration accepted")
+	}
+}
@@ -0,0 +1,60 @@
+package baseError
+
+import (
+	"crypto/sha1"
+	"encoding/hex"
+	"fmt"
+	"strings"
+)
+
+// Fingerprint identifies where and how err was created: the code plus the captured call
+// sites. Messages are ignored so formatted arguments don't split one failure into many.
+// Foreign errors are identified by their type and the top frame of their stack, or, when
+// they have none, by their message with numbers and quoted strings blanked out.
+func Fingerprint(err error) string {
+	if isNil(err) {
+		return ""
+	}
+	h := sha1.New()
+	e := errorOf(err)
+	if e == nil {
+		fmt.Fprintf(h, "%T", err)
+		if f, ok := innermostFrame(err); ok {
+			fmt.Fprintf(h, "\n%s:%d", f.Function, f.Line)
+		} else {
+			fmt.Fprintf(h, "\n%s", messageShape(err.Error()))
+		}
+		return hex.EncodeToString(h.Sum(nil))[:16]
+	}
+	h.Write([]byte(e.Code))
+	for _, f := range e.stack.Frames() {
+		fmt.Fprintf(h, "\n%s:%d", f.Function, f.Line)
+	}
+	return hex.EncodeToString(h.Sum(nil))[:16]
+}
+
+// messageShape blanks out the runs of digits and the quoted strings of msg, which are
+// usually formatted arguments: `user "bob" not found after 3 tries` becomes
+// `user "" not found after # tries`.
+func messageShape(msg string) string {
+	var b strings.Builder
+	for i := 0; i < len(msg); i++ {
+		switch c := msg[i]; {
+		case '0' <= c && c <= '9':
+			for i+1 < len(msg) && '0' <= msg[i+1] && msg[i+1] <= '9' {
+				i++
+			}
+			b.WriteByte('#')
+		case c == '"':
+			if end := strings.IndexByte(msg[i+1:], '"'); end >= 0 {
+				i += end + 1
+				b.WriteString(`""`)
+				continue
+			}
+			b.WriteByte(c)
+		default:
+			b.WriteByte(c)
+		}
+	}
+	return b.String()
+}
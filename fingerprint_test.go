@@ -0,0 +1,46 @@
+package baseError
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	pkgerrors "github.com/pkg/errors"
+)
+
+func TestFingerprint(t *testing.T) {
+	newErr := func(msg string) *Error {
+		return NewStack("FP", msg, 5)
+	}
+	a, b := newErr("a"), newErr("b")
+	if Fingerprint(a) != Fingerprint(b) {
+		t.Error("errors created at the same site should share a fingerprint")
+	}
+	if Fingerprint(a) == Fingerprint(NewStack("FP", "a", 5)) {
+		t.Error("errors created at different sites should not share a fingerprint")
+	}
+	if Fingerprint(New("FP", "")) == Fingerprint(New("FP2", "")) {
+		t.Error("different codes should not share a fingerprint")
+	}
+}
+
+func TestFingerprintForeign(t *testing.T) {
+	timeout := func(n int) error { return fmt.Errorf("timeout after %d tries", n) }
+	if Fingerprint(timeout(3)) != Fingerprint(timeout(12)) {
+		t.Error("foreign errors differing only in numbers should share a fingerprint")
+	}
+	if Fingerprint(errors.New("disk full")) == Fingerprint(errors.New("permission denied")) {
+		t.Error("different foreign messages should not share a fingerprint")
+	}
+	if Fingerprint(fmt.Errorf("user %q not found", "bob")) != Fingerprint(fmt.Errorf("user %q not found", "alice")) {
+		t.Error("quoted arguments should not split a fingerprint")
+	}
+
+	newErr := func(msg string) error { return pkgerrors.New(msg) }
+	if Fingerprint(newErr("a")) != Fingerprint(newErr("b")) {
+		t.Error("foreign errors from the same site should share a fingerprint")
+	}
+	if Fingerprint(newErr("a")) == Fingerprint(pkgerrors.New("a")) {
+		t.Error("foreign errors from different sites should not share a fingerprint")
+	}
+}
@@ -0,0 +1,41 @@
+package baseError
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func TestFormatVerbs(t *testing.T) {
+	err := New("AB", "cd")
+	for format, want := range map[string]string{
+		"%s":     "[AB] cd",
+		"%v":     "[AB] cd",
+		"%10.4s": "      [AB]",
+		"%-9s|":  "[AB] cd  |",
+		"%q":     `"[AB] cd"`,
+		"%x":     Fingerprint(err),
+		"%X":     strings.ToUpper(Fingerprint(err)),
+		"%d":     "%!d(*baseError.Error=[AB] cd)",
+	} {
+		if got := fmt.Sprintf(format, err); got != want {
+			t.Errorf("Sprintf(%q) = %q, want %q", format, got, want)
+		}
+	}
+}
+
+func FuzzFormat(f *testing.F) {
+	for _, seed := range []string{"%v", "%+v", "%#v", "%08.3q", "%-x", "% X", "%d", "%t", "%[1]s"} {
+		f.Add(seed, "CODE", "message")
+	}
+	f.Fuzz(func(t *testing.T, format, code, msg string) {
+		if !strings.HasPrefix(format, "%") {
+			return
+		}
+		err := New(code, msg)
+		// A zero precision may legitimately print nothing.
+		if out := fmt.Sprintf(format, err); out == "" && !strings.Contains(format, ".") && format != "%" {
+			t.Errorf("Sprintf(%q) printed nothing", format)
+		}
+	})
+}
@@ -0,0 +1,31 @@
+package gateway
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+
+	"github.com/go-tron/base-error/grpcx"
+	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
+	"google.golang.org/grpc/status"
+)
+
+var _ runtime.ErrorHandlerFunc = ErrorHandler
+
+// ErrorHandler renders gRPC statuses as the standard {"code","msg"} envelope. The code is
+// taken from an ErrorInfo detail's reason when present, otherwise from the gRPC code name.
+func ErrorHandler(ctx context.Context, mux *runtime.ServeMux, m runtime.Marshaler, w http.ResponseWriter, r *http.Request, err error) {
+	st := status.Convert(err)
+	e := grpcx.FromStatus(st)
+
+	buf, merr := json.Marshal(e)
+	if merr != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+	w.Header().Del("Trailer")
+	w.Header().Del("Transfer-Encoding")
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(runtime.HTTPStatusFromCode(st.Code()))
+	w.Write(buf)
+}
@@ -0,0 +1,36 @@
+package gateway
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-tron/base-error/grpcx"
+	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestErrorHandler(t *testing.T) {
+	st, _ := status.New(codes.NotFound, "order not found").WithDetails(&errdetails.ErrorInfo{Reason: "ORDER_NOT_FOUND"})
+
+	w := httptest.NewRecorder()
+	r := httptest.NewRequest(http.MethodGet, "/orders/1", nil)
+	ErrorHandler(context.Background(), runtime.NewServeMux(), &runtime.JSONPb{}, w, r, st.Err())
+
+	if w.Code != http.StatusNotFound {
+		t.Errorf("status = %d", w.Code)
+	}
+	if body := w.Body.String(); body != `{"code":"ORDER_NOT_FOUND","msg":"order not found"}` {
+		t.Errorf("body = %s", body)
+	}
+}
+
+func TestFromStatus(t *testing.T) {
+	err := grpcx.FromStatus(status.New(codes.Unavailable, "db down"))
+	if err.Code != "UNAVAILABLE" || !err.System {
+		t.Errorf("got %v system=%v", err, err.System)
+	}
+}
@@ -0,0 +1,63 @@
+package baseError
+
+import "fmt"
+
+// Factory1 is Factory for templates with exactly one placeholder, typed so that
+// the compiler checks the argument. It panics if the template's placeholder count
+// doesn't match.
+func Factory1[T any](code string, msg string) func(T) *Error {
+	format := typedFormat(code, msg, 1)
+	return func(a T) *Error {
+		msg, args := format(a)
+		return created(&Error{Code: code, Msg: msg, args: args})
+	}
+}
+
+func Factory2[T1, T2 any](code string, msg string) func(T1, T2) *Error {
+	format := typedFormat(code, msg, 2)
+	return func(a T1, b T2) *Error {
+		msg, args := format(a, b)
+		return created(&Error{Code: code, Msg: msg, args: args})
+	}
+}
+
+func Factory3[T1, T2, T3 any](code string, msg string) func(T1, T2, T3) *Error {
+	format := typedFormat(code, msg, 3)
+	return func(a T1, b T2, c T3) *Error {
+		msg, args := format(a, b, c)
+		return created(&Error{Code: code, Msg: msg, args: args})
+	}
+}
+
+func SystemFactory1[T any](code string, msg string) func(T) *Error {
+	format := typedFormat(code, msg, 1)
+	return func(a T) *Error {
+		msg, args := format(a)
+		return created(&Error{Code: code, Msg: msg, System: true, args: args})
+	}
+}
+
+func SystemFactory2[T1, T2 any](code string, msg string) func(T1, T2) *Error {
+	format := typedFormat(code, msg, 2)
+	return func(a T1, b T2) *Error {
+		msg, args := format(a, b)
+		return created(&Error{Code: code, Msg: msg, System: true, args: args})
+	}
+}
+
+func SystemFactory3[T1, T2, T3 any](code string, msg string) func(T1, T2, T3) *Error {
+	format := typedFormat(code, msg, 3)
+	return func(a T1, b T2, c T3) *Error {
+		msg, args := format(a, b, c)
+		return created(&Error{Code: code, Msg: msg, System: true, args: args})
+	}
+}
+
+func typedFormat(code string, msg string, arity int) func(message ...interface{}) (string, []interface{}) {
+	if n := placeholderCount(msg); n != arity {
+		panic(fmt.Sprintf("baseError: %s: template %q has %d placeholders, want %d", code, msg, n, arity))
+	}
+	registerFactory(DefaultRegistry, cfg(), code, []string{code, msg})
+	_, format := factoryFormat(code, msg)
+	return format
+}
@@ -0,0 +1,35 @@
+package baseError
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestGenericFactories(t *testing.T) {
+	notFound := Factory1[int]("GEN_USER_NOT_FOUND", "user {} not found")
+	if e := notFound(7); e.Code != "GEN_USER_NOT_FOUND" || e.Msg != "user 7 not found" || e.System {
+		t.Errorf("e = %+v", e)
+	}
+	moved := Factory2[string, string]("GEN_MOVED", "{from} moved to {to}")
+	if e := moved("a", "b"); e.Msg != "a moved to b" {
+		t.Errorf("msg = %q", e.Msg)
+	}
+	quota := SystemFactory3[string, int, int]("GEN_QUOTA", "{} used {} of {}")
+	if e := quota("disk", 9, 10); e.Msg != "disk used 9 of 10" || !e.System {
+		t.Errorf("e = %+v", e)
+	}
+
+	RegisterMessage("GEN_USER_NOT_FOUND", "de", "Benutzer {} nicht gefunden")
+	if got := notFound(7).Localize("de"); got != "Benutzer 7 nicht gefunden" {
+		t.Errorf("localized = %q", got)
+	}
+}
+
+func TestGenericFactoryArity(t *testing.T) {
+	defer func() {
+		if r := recover(); r == nil || !strings.Contains(r.(string), "has 1 placeholders, want 2") {
+			t.Errorf("recover = %v", r)
+		}
+	}()
+	Factory2[int, int]("GEN_BAD", "only {}")
+}
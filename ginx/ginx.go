@@ -0,0 +1,22 @@
+// Package ginx renders baseError values from gin handlers.
+package ginx
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/go-tron/base-error/httpx"
+)
+
+// ErrorHandler returns a middleware that, once the handlers have run, writes the last
+// error in c.Errors with httpx.WriteError and aborts. The status comes from the registry
+// and System errors are sanitized. Nothing is written when the handlers already wrote a
+// response.
+func ErrorHandler() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		c.Next()
+		if len(c.Errors) == 0 || c.Writer.Written() {
+			return
+		}
+		httpx.WriteError(c.Writer, c.Errors.Last().Err)
+		c.Abort()
+	}
+}
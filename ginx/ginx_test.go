@@ -0,0 +1,52 @@
+package ginx
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	baseError "github.com/go-tron/base-error"
+)
+
+func TestErrorHandler(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	baseError.RegisterHTTPStatus("GINX_ORDER_NOT_FOUND", http.StatusNotFound)
+
+	r := gin.New()
+	r.Use(ErrorHandler())
+	r.GET("/orders", func(c *gin.Context) {
+		c.Error(baseError.New("GINX_ORDER_NOT_FOUND", "order not found"))
+	})
+	r.GET("/db", func(c *gin.Context) {
+		c.Error(errors.New("dial tcp: refused"))
+	})
+	r.GET("/written", func(c *gin.Context) {
+		c.String(http.StatusAccepted, "done")
+		c.Error(baseError.New("GINX_LATE", "late"))
+	})
+
+	for _, tc := range []struct {
+		path, body string
+		status     int
+	}{
+		{"/orders", `{"code":"GINX_ORDER_NOT_FOUND","msg":"order not found"}`, http.StatusNotFound},
+		{"/written", "done", http.StatusAccepted},
+	} {
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, tc.path, nil))
+		if w.Code != tc.status || w.Body.String() != tc.body {
+			t.Errorf("%s: %d %s", tc.path, w.Code, w.Body)
+		}
+	}
+
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/db", nil))
+	if w.Code != http.StatusInternalServerError || w.Header().Get("Content-Type") != "application/json; charset=utf-8" {
+		t.Errorf("/db: %d %v", w.Code, w.Header())
+	}
+	if e, err := baseError.Parse(w.Body.Bytes()); err != nil || e.Code != baseError.CurrentConfig().SanitizedCode {
+		t.Errorf("/db body = %s", w.Body)
+	}
+}
@@ -1,5 +1,8 @@
 module github.com/go-tron/base-error
 
-go 1.19
+go 1.20
 
-require github.com/pkg/errors v0.9.1
+require (
+	github.com/pkg/errors v0.9.1
+	golang.org/x/text v0.14.0
+)
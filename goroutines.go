@@ -0,0 +1,60 @@
+package baseError
+
+import (
+	"fmt"
+	"runtime"
+	"strings"
+)
+
+const (
+	maxGoroutineDump   = 1 << 20
+	maxGoroutines      = 64
+	maxGoroutineFrames = 8
+)
+
+// CaptureAllGoroutines attaches a snapshot of every goroutine's stack to err's debug
+// details, one line per goroutine with its state and top frames. It only acts on
+// System errors of SeverityCritical, since a full dump stops the world; the snapshot is
+// bounded to 64 goroutines of 8 frames each. Other errors are returned unchanged.
+func CaptureAllGoroutines(err error) error {
+	e := errorOf(err)
+	if e == nil || !e.System || SeverityOf(e) < SeverityCritical {
+		return err
+	}
+	buf := make([]byte, maxGoroutineDump)
+	buf = buf[:runtime.Stack(buf, true)]
+	e.WithDebugDetails(parseGoroutines(string(buf))...)
+	return err
+}
+
+// parseGoroutines condenses a runtime.Stack dump into
+// "goroutine 7 [chan receive]: pkg.fn (file.go:12) <- pkg.caller (file.go:30)".
+func parseGoroutines(dump string) []string {
+	blocks := strings.Split(strings.TrimSpace(dump), "\n\n")
+	var out []string
+	for i, block := range blocks {
+		if i == maxGoroutines {
+			out = append(out, fmt.Sprintf("... %d more goroutines", len(blocks)-i))
+			break
+		}
+		lines := strings.Split(block, "\n")
+		header := strings.TrimSuffix(lines[0], ":")
+		var frames []string
+		for j := 1; j+1 < len(lines) && len(frames) < maxGoroutineFrames; j += 2 {
+			fn := lines[j]
+			if k := strings.LastIndex(fn, "("); k > 0 {
+				fn = fn[:k]
+			}
+			loc := strings.TrimSpace(lines[j+1])
+			if k := strings.LastIndex(loc, " +0x"); k > 0 {
+				loc = loc[:k]
+			}
+			if k := strings.LastIndex(loc, "/"); k >= 0 {
+				loc = loc[k+1:]
+			}
+			frames = append(frames, fmt.Sprintf("%s (%s)", fn, loc))
+		}
+		out = append(out, header+": "+strings.Join(frames, " <- "))
+	}
+	return out
+}
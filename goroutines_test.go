@@ -0,0 +1,42 @@
+package baseError
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestCaptureAllGoroutines(t *testing.T) {
+	block := make(chan struct{})
+	defer close(block)
+	go func() { <-block }()
+
+	e := System("DEADLOCK", "timed out").WithSeverity(SeverityCritical)
+	CaptureAllGoroutines(e)
+	var found bool
+	for _, d := range e.DebugDetails() {
+		if strings.HasPrefix(d, "goroutine ") && strings.Contains(d, "[chan receive]") {
+			found = true
+		}
+	}
+	if !found {
+		t.Errorf("blocked goroutine missing from %q", e.DebugDetails())
+	}
+
+	low := System("SLOW", "timed out")
+	CaptureAllGoroutines(low)
+	if len(low.DebugDetails()) != 0 {
+		t.Error("captured below SeverityCritical")
+	}
+}
+
+func TestParseGoroutines(t *testing.T) {
+	dump := "goroutine 1 [running]:\nmain.main()\n\t/src/app/main.go:10 +0x1d\n\ngoroutine 7 [select]:\nmain.worker(0x1)\n\t/src/app/worker.go:22 +0x45\ncreated by main.main in goroutine 1\n\t/src/app/main.go:8 +0x2b"
+	got := parseGoroutines(dump)
+	want := []string{
+		"goroutine 1 [running]: main.main (main.go:10)",
+		"goroutine 7 [select]: main.worker (worker.go:22) <- created by main.main in goroutine 1 (main.go:8)",
+	}
+	if len(got) != 2 || got[0] != want[0] || got[1] != want[1] {
+		t.Errorf("got %q", got)
+	}
+}
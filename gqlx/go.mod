@@ -0,0 +1,19 @@
+module github.com/go-tron/base-error/gqlx
+
+go 1.26.0
+
+require (
+	github.com/99designs/gqlgen v0.17.95
+	github.com/go-tron/base-error v0.0.0-00010101000000-000000000000
+	github.com/vektah/gqlparser/v2 v2.5.37
+)
+
+require (
+	github.com/google/uuid v1.6.0 // indirect
+	github.com/pkg/errors v0.9.1 // indirect
+	github.com/sosodev/duration v1.4.0 // indirect
+	golang.org/x/sync v0.23.0 // indirect
+	golang.org/x/text v0.42.0 // indirect
+)
+
+replace github.com/go-tron/base-error => ../
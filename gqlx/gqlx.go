@@ -0,0 +1,72 @@
+// Package gqlx presents baseError values as GraphQL errors for gqlgen servers.
+package gqlx
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/99designs/gqlgen/graphql"
+	baseError "github.com/go-tron/base-error"
+	"github.com/vektah/gqlparser/v2/gqlerror"
+)
+
+type options struct {
+	stack bool
+}
+
+type Option func(*options)
+
+// WithStack adds the stack of the error as extensions.stack. It exposes source locations,
+// so enable it in development only.
+func WithStack(on bool) Option {
+	return func(o *options) { o.stack = on }
+}
+
+// Presenter returns a gqlgen error presenter. Resolver errors get the message of their
+// sanitized form, extensions.code, extensions.fields with the fields ToProblem exposes
+// and extensions.ref for System and foreign errors. gqlgen's own parsing and validation
+// errors are presented by its default presenter. Install it with
+// srv.SetErrorPresenter(gqlx.Presenter()).
+func Presenter(opts ...Option) graphql.ErrorPresenterFunc {
+	o := &options{}
+	for _, opt := range opts {
+		opt(o)
+	}
+	return func(ctx context.Context, err error) *gqlerror.Error {
+		gqlErr := graphql.DefaultErrorPresenter(ctx, err)
+		if gqlErr == nil {
+			return nil
+		}
+		var e *baseError.Error
+		if errors.As(err, &e) && e != nil {
+			err = e
+		} else if gqlErr.Err == nil {
+			return gqlErr
+		}
+		p := baseError.ToProblem(err)
+		gqlErr.Message = p.Detail
+		ext := make(map[string]interface{}, len(gqlErr.Extensions)+3)
+		for k, v := range gqlErr.Extensions {
+			ext[k] = v
+		}
+		ext["code"] = p.Code
+		if len(p.Extensions) > 0 {
+			ext["fields"] = p.Extensions
+		}
+		if p.Instance != "" {
+			ext["ref"] = p.Instance
+		}
+		if o.stack {
+			if frames := baseError.StackFrames(err); len(frames) > 0 {
+				stack := make([]string, len(frames))
+				for i, f := range frames {
+					stack[i] = fmt.Sprintf("%s %s:%d", f.Function, f.File, f.Line)
+				}
+				ext["stack"] = stack
+			}
+		}
+		gqlErr.Extensions = ext
+		return gqlErr
+	}
+}
@@ -0,0 +1,50 @@
+package gqlx
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/99designs/gqlgen/graphql"
+	baseError "github.com/go-tron/base-error"
+	"github.com/vektah/gqlparser/v2/ast"
+	"github.com/vektah/gqlparser/v2/gqlerror"
+)
+
+func TestPresenter(t *testing.T) {
+	ctx := graphql.WithPathContext(context.Background(), graphql.NewPathWithField("order"))
+	present := Presenter()
+
+	err := baseError.New("GQLX_ORDER_NOT_FOUND", "order not found").WithField("order_id", "o1")
+	got := present(ctx, graphql.ErrorOnPath(ctx, err))
+	if got.Message != "order not found" || got.Extensions["code"] != "GQLX_ORDER_NOT_FOUND" {
+		t.Errorf("got %+v", got)
+	}
+	if f, _ := got.Extensions["fields"].(map[string]interface{}); f["order_id"] != "o1" {
+		t.Errorf("fields = %v", got.Extensions["fields"])
+	}
+	if got.Path.String() != (ast.Path{ast.PathName("order")}).String() {
+		t.Errorf("path = %v", got.Path)
+	}
+	if _, ok := got.Extensions["stack"]; ok {
+		t.Error("stack exposed outside dev mode")
+	}
+
+	got = Presenter(WithStack(true))(ctx, baseError.WrapStack("GQLX_DB", errors.New("password=hunter2"), 4))
+	if strings.Contains(got.Message, "hunter2") || got.Extensions["code"] != baseError.CurrentConfig().SanitizedCode || got.Extensions["ref"] == nil {
+		t.Errorf("system got %+v", got)
+	}
+	if st, _ := got.Extensions["stack"].([]string); len(st) == 0 {
+		t.Error("dev stack missing")
+	}
+
+	got = present(ctx, errors.New("raw secret"))
+	if got.Message != baseError.CurrentConfig().SanitizedMsg || got.Extensions["code"] != baseError.CurrentConfig().SanitizedCode || got.Extensions["ref"] == nil {
+		t.Errorf("foreign got %+v", got)
+	}
+
+	if got = present(ctx, gqlerror.Errorf("Cannot query field %q", "x")); got.Message != `Cannot query field "x"` || got.Extensions != nil {
+		t.Errorf("gqlgen error got %+v", got)
+	}
+}
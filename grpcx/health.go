@@ -0,0 +1,33 @@
+// Package grpcx integrates baseError with gRPC servers and clients.
+package grpcx
+
+import (
+	"context"
+
+	baseError "github.com/go-tron/base-error"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+)
+
+// HealthResponse converts report to a grpc_health_v1 response. The protocol has no
+// degraded state, so a degraded service reports SERVING.
+func HealthResponse(report baseError.HealthReport) *healthpb.HealthCheckResponse {
+	status := healthpb.HealthCheckResponse_SERVING
+	if report.Status == baseError.HealthNotServing {
+		status = healthpb.HealthCheckResponse_NOT_SERVING
+	}
+	return &healthpb.HealthCheckResponse{Status: status}
+}
+
+type healthServer struct {
+	healthpb.UnimplementedHealthServer
+	check func(ctx context.Context) baseError.HealthReport
+}
+
+// NewHealthServer returns a grpc_health_v1 server answering Check with the result of check.
+func NewHealthServer(check func(ctx context.Context) baseError.HealthReport) healthpb.HealthServer {
+	return &healthServer{check: check}
+}
+
+func (s *healthServer) Check(ctx context.Context, _ *healthpb.HealthCheckRequest) (*healthpb.HealthCheckResponse, error) {
+	return HealthResponse(s.check(ctx)), nil
+}
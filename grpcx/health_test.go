@@ -0,0 +1,31 @@
+package grpcx
+
+import (
+	"context"
+	"testing"
+
+	baseError "github.com/go-tron/base-error"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+)
+
+func TestHealthServer(t *testing.T) {
+	deps := map[string]error{"db": nil}
+	srv := NewHealthServer(func(ctx context.Context) baseError.HealthReport {
+		return baseError.CheckHealth(deps, nil)
+	})
+
+	for _, c := range []struct {
+		err  error
+		want healthpb.HealthCheckResponse_ServingStatus
+	}{
+		{nil, healthpb.HealthCheckResponse_SERVING},
+		{context.DeadlineExceeded, healthpb.HealthCheckResponse_SERVING},
+		{baseError.System("DB_DOWN", ""), healthpb.HealthCheckResponse_NOT_SERVING},
+	} {
+		deps["db"] = c.err
+		resp, _ := srv.Check(context.Background(), &healthpb.HealthCheckRequest{})
+		if resp.Status != c.want {
+			t.Errorf("%v: status = %s", c.err, resp.Status)
+		}
+	}
+}
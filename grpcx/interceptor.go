@@ -0,0 +1,106 @@
+package grpcx
+
+import (
+	"context"
+	"errors"
+	"log"
+
+	baseError "github.com/go-tron/base-error"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/status"
+)
+
+// Logger receives every error an interceptor converts, with the full method name.
+type Logger func(ctx context.Context, method string, err error)
+
+type options struct {
+	logger   Logger
+	minLevel baseError.Level
+}
+
+type Option func(*options)
+
+// WithLogger replaces the default logger, which prints %+v through the standard log
+// package.
+func WithLogger(l Logger) Option {
+	return func(o *options) { o.logger = l }
+}
+
+// WithLogLevel sets the minimum level logged, LevelWarn by default.
+func WithLogLevel(level baseError.Level) Option {
+	return func(o *options) { o.minLevel = level }
+}
+
+func newOptions(opts []Option) *options {
+	o := &options{
+		logger: func(_ context.Context, method string, err error) {
+			log.Printf("%s: %+v", method, err)
+		},
+		minLevel: baseError.LevelWarn,
+	}
+	for _, opt := range opts {
+		opt(o)
+	}
+	return o
+}
+
+// UnaryServerInterceptor converts errors returned by handlers, and panics, to gRPC
+// statuses with ToStatus after sanitizing them, so clients never see System messages or
+// fields; the logger gets the full error. The gRPC code and origin still follow the
+// original error. Errors that already are statuses pass through unchanged and context errors
+// become Canceled or DeadlineExceeded.
+func UnaryServerInterceptor(opts ...Option) grpc.UnaryServerInterceptor {
+	o := newOptions(opts)
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (resp interface{}, err error) {
+		defer func() {
+			if r := recover(); r != nil {
+				resp, err = nil, o.convert(ctx, info.FullMethod, baseError.FromPanic(r))
+			}
+		}()
+		resp, err = handler(ctx, req)
+		return resp, o.convert(ctx, info.FullMethod, err)
+	}
+}
+
+// StreamServerInterceptor is UnaryServerInterceptor for streaming RPCs.
+func StreamServerInterceptor(opts ...Option) grpc.StreamServerInterceptor {
+	o := newOptions(opts)
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) (err error) {
+		defer func() {
+			if r := recover(); r != nil {
+				err = o.convert(ss.Context(), info.FullMethod, baseError.FromPanic(r))
+			}
+		}()
+		return o.convert(ss.Context(), info.FullMethod, handler(srv, ss))
+	}
+}
+
+func (o *options) convert(ctx context.Context, method string, err error) error {
+	if err == nil {
+		return nil
+	}
+	if e, ok := err.(*baseError.Error); ok && e == nil {
+		return nil
+	}
+	_, isStatus := err.(interface{ GRPCStatus() *status.Status })
+	isContext := baseError.CodeOfOr(err, "") == "" && (errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded))
+	sanitized, logged := baseError.SanitizeLogged(err)
+	if baseError.ShouldLog(err, o.minLevel) {
+		o.logger(ctx, method, logged)
+	}
+	if isStatus {
+		return err
+	}
+	if isContext {
+		return status.FromContextError(err).Err()
+	}
+	var orig *baseError.Error
+	if errors.As(err, &orig) {
+		sanitized.WithOrigin(orig.Origin())
+	}
+	st := ToStatus(sanitized).Proto()
+	if orig != nil {
+		st.Code = int32(grpcCode(orig))
+	}
+	return status.FromProto(st).Err()
+}
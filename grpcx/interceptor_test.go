@@ -0,0 +1,101 @@
+package grpcx
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	baseError "github.com/go-tron/base-error"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestUnaryServerInterceptor(t *testing.T) {
+	var logged []string
+	var lastRef interface{}
+	intercept := UnaryServerInterceptor(WithLogger(func(_ context.Context, method string, err error) {
+		logged = append(logged, method+" "+baseError.CodeOf(err))
+		if e, ok := err.(*baseError.Error); ok {
+			lastRef = e.Fields()[baseError.FieldReference]
+		}
+	}))
+	info := &grpc.UnaryServerInfo{FullMethod: "/orders.Orders/Get"}
+	call := func(h grpc.UnaryHandler) error {
+		_, err := intercept(context.Background(), nil, info, h)
+		return err
+	}
+
+	err := call(func(ctx context.Context, req interface{}) (interface{}, error) {
+		return nil, baseError.New("GRPCX_ORDER_MISSING", "order missing")
+	})
+	if st := status.Convert(err); st.Message() != "order missing" || FromStatus(st).Code != "GRPCX_ORDER_MISSING" {
+		t.Errorf("status = %v", st)
+	}
+
+	err = call(func(ctx context.Context, req interface{}) (interface{}, error) {
+		panic("boom")
+	})
+	if st := status.Convert(err); st.Code() != codes.Internal || st.Message() != "internal error" || FromStatus(st).Code != "INTERNAL_ERROR" {
+		t.Errorf("panic status = %v", st)
+	}
+
+	dbDown := baseError.System("GRPCX_DB_DOWN", "dial 10.0.0.7: refused").WithField("dsn", "secret")
+	err = call(func(ctx context.Context, req interface{}) (interface{}, error) {
+		return nil, dbDown
+	})
+	st := status.Convert(err)
+	if e := FromStatus(st); st.Message() != "internal error" || e.Fields()["dsn"] != nil || e.Fields()[baseError.FieldReference] == nil || e.Fields()[baseError.FieldReference] != lastRef {
+		t.Errorf("system status = %v %+v, logged ref %v", st, e, lastRef)
+	}
+	if _, ok := dbDown.Fields()[baseError.FieldReference]; ok {
+		t.Error("handler error modified")
+	}
+	if o := FromStatus(st).Origin(); o != dbDown.Origin() {
+		t.Errorf("origin = %+v, want %+v", o, dbDown.Origin())
+	}
+	if err = call(func(ctx context.Context, req interface{}) (interface{}, error) {
+		return nil, errors.New("raw secret")
+	}); status.Convert(err).Message() != "internal error" {
+		t.Errorf("foreign status = %v", err)
+	}
+
+	err = call(func(ctx context.Context, req interface{}) (interface{}, error) {
+		return nil, context.DeadlineExceeded
+	})
+	if status.Code(err) != codes.DeadlineExceeded {
+		t.Errorf("deadline status = %v", err)
+	}
+
+	passthrough := status.Error(codes.NotFound, "nope")
+	if err = call(func(ctx context.Context, req interface{}) (interface{}, error) {
+		return nil, passthrough
+	}); !errors.Is(err, passthrough) {
+		t.Errorf("status error not passed through: %v", err)
+	}
+
+	if err = call(func(ctx context.Context, req interface{}) (interface{}, error) {
+		var e *baseError.Error
+		return "ok", e
+	}); err != nil {
+		t.Errorf("nil *Error converted to %v", err)
+	}
+	if len(logged) != 6 || !strings.HasPrefix(logged[1], "/orders.Orders/Get PANIC") || logged[2] != "/orders.Orders/Get GRPCX_DB_DOWN" {
+		t.Errorf("logged = %v", logged)
+	}
+}
+
+type fakeStream struct{ grpc.ServerStream }
+
+func (fakeStream) Context() context.Context { return context.Background() }
+
+func TestStreamServerInterceptor(t *testing.T) {
+	intercept := StreamServerInterceptor(WithLogLevel(baseError.LevelError + 1))
+	err := intercept(nil, fakeStream{}, &grpc.StreamServerInfo{FullMethod: "/orders.Orders/Watch"}, func(srv interface{}, ss grpc.ServerStream) error {
+		panic(errors.New("stream boom"))
+	})
+	if e := FromError(err); e.Code != "INTERNAL_ERROR" || !e.System || strings.Contains(e.Msg, "boom") {
+		t.Errorf("got %v", e)
+	}
+}
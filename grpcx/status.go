@@ -0,0 +1,181 @@
+package grpcx
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+
+	baseError "github.com/go-tron/base-error"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// ErrorInfo metadata keys used for the parts of *Error that aren't fields.
+const (
+	MetadataChain         = "baseerror.chain"
+	MetadataSystem        = "baseerror.system"
+	MetadataOriginService = "baseerror.origin.service"
+	MetadataOriginVersion = "baseerror.origin.version"
+	MetadataOriginZone    = "baseerror.origin.zone"
+)
+
+// ToStatus converts err to a gRPC status. The code, System flag, chain, origin and fields
+// travel in an ErrorInfo detail, field values in their fmt form. Msg is sent as is, so
+// System errors crossing a public boundary should be sanitized first.
+func ToStatus(err error) *status.Status {
+	if err == nil {
+		return status.New(codes.OK, "")
+	}
+	var e *baseError.Error
+	if !errors.As(err, &e) {
+		return status.New(codes.Unknown, err.Error())
+	}
+	st := status.New(grpcCode(e), e.Msg)
+	info := &errdetails.ErrorInfo{Reason: e.Code}
+	for k, v := range e.Fields() {
+		if info.Metadata == nil {
+			info.Metadata = make(map[string]string)
+		}
+		info.Metadata[k] = fmt.Sprint(v)
+	}
+	origin := e.Origin()
+	if e.Chain != "" || e.System || !origin.IsZero() {
+		if info.Metadata == nil {
+			info.Metadata = make(map[string]string)
+		}
+		if e.Chain != "" {
+			info.Metadata[MetadataChain] = e.Chain
+		}
+		if e.System {
+			info.Metadata[MetadataSystem] = "true"
+		}
+		for k, v := range map[string]string{
+			MetadataOriginService: origin.Service,
+			MetadataOriginVersion: origin.Version,
+			MetadataOriginZone:    origin.Zone,
+		} {
+			if v != "" {
+				info.Metadata[k] = v
+			}
+		}
+	}
+	if withInfo, derr := st.WithDetails(info); derr == nil {
+		st = withInfo
+	}
+	return st
+}
+
+// FromStatus converts st back to *Error. Without an ErrorInfo detail the code is the gRPC
+// code name and statuses signalling server faults are System errors. Like
+// baseError.Parse, it rebuilds the error rather than creating it: the error keeps the
+// origin the server sent instead of being stamped with the local one, and creation hooks
+// don't run.
+func FromStatus(st *status.Status) *baseError.Error {
+	if st == nil || st.Code() == codes.OK {
+		return nil
+	}
+	var info *errdetails.ErrorInfo
+	for _, d := range st.Details() {
+		if i, ok := d.(*errdetails.ErrorInfo); ok && i.Reason != "" {
+			info = i
+			break
+		}
+	}
+	if info == nil {
+		e := &baseError.Error{Code: CodeName(st.Code()), Msg: st.Message()}
+		switch st.Code() {
+		case codes.Unknown, codes.Internal, codes.Unavailable, codes.DataLoss:
+			e.System = true
+		}
+		return e
+	}
+
+	e := &baseError.Error{Code: info.Reason, Msg: st.Message(), System: info.Metadata[MetadataSystem] == "true"}
+	var origin baseError.Origin
+	for k, v := range info.Metadata {
+		switch k {
+		case MetadataChain:
+			e.Chain = v
+		case MetadataOriginService:
+			origin.Service = v
+		case MetadataOriginVersion:
+			origin.Version = v
+		case MetadataOriginZone:
+			origin.Zone = v
+		case MetadataSystem:
+		default:
+			e.WithField(k, v)
+		}
+	}
+	return e.WithOrigin(origin)
+}
+
+// FromError converts an error returned by a gRPC client call to *Error.
+func FromError(err error) *baseError.Error {
+	if err == nil {
+		return nil
+	}
+	return FromStatus(status.Convert(err))
+}
+
+var codeNames = map[codes.Code]string{
+	codes.OK:                 "OK",
+	codes.Canceled:           "CANCELLED",
+	codes.Unknown:            "UNKNOWN",
+	codes.InvalidArgument:    "INVALID_ARGUMENT",
+	codes.DeadlineExceeded:   "DEADLINE_EXCEEDED",
+	codes.NotFound:           "NOT_FOUND",
+	codes.AlreadyExists:      "ALREADY_EXISTS",
+	codes.PermissionDenied:   "PERMISSION_DENIED",
+	codes.ResourceExhausted:  "RESOURCE_EXHAUSTED",
+	codes.FailedPrecondition: "FAILED_PRECONDITION",
+	codes.Aborted:            "ABORTED",
+	codes.OutOfRange:         "OUT_OF_RANGE",
+	codes.Unimplemented:      "UNIMPLEMENTED",
+	codes.Internal:           "INTERNAL",
+	codes.Unavailable:        "UNAVAILABLE",
+	codes.DataLoss:           "DATA_LOSS",
+	codes.Unauthenticated:    "UNAUTHENTICATED",
+}
+
+// CodeName returns the canonical upper-case name of c, e.g. NOT_FOUND.
+func CodeName(c codes.Code) string {
+	if name, ok := codeNames[c]; ok {
+		return name
+	}
+	return "UNKNOWN"
+}
+
+var httpCodes = map[int]codes.Code{
+	http.StatusBadRequest:          codes.InvalidArgument,
+	http.StatusUnauthorized:        codes.Unauthenticated,
+	http.StatusForbidden:           codes.PermissionDenied,
+	http.StatusNotFound:            codes.NotFound,
+	http.StatusConflict:            codes.Aborted,
+	http.StatusPreconditionFailed:  codes.FailedPrecondition,
+	http.StatusTooManyRequests:     codes.ResourceExhausted,
+	499:                            codes.Canceled,
+	http.StatusNotImplemented:      codes.Unimplemented,
+	http.StatusServiceUnavailable:  codes.Unavailable,
+	http.StatusGatewayTimeout:      codes.DeadlineExceeded,
+	http.StatusInternalServerError: codes.Internal,
+}
+
+// grpcCode picks the gRPC code for e: the code itself when it is a gRPC code name,
+// otherwise the one matching its HTTP status.
+func grpcCode(e *baseError.Error) codes.Code {
+	for c, name := range codeNames {
+		if name == e.Code && c != codes.OK {
+			return c
+		}
+	}
+	status := baseError.HTTPStatus(e)
+	if c, ok := httpCodes[status]; ok {
+		return c
+	}
+	if status >= 500 {
+		return codes.Internal
+	}
+	return codes.FailedPrecondition
+}
@@ -0,0 +1,81 @@
+package grpcx
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+
+	baseError "github.com/go-tron/base-error"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestStatusRoundTrip(t *testing.T) {
+	orig := baseError.System("GRPCX_LEDGER_DOWN", "ledger unreachable").
+		WithChain("charge", "ledger.Post").
+		WithField("account", 42)
+
+	st := ToStatus(orig)
+	if st.Code() != codes.Internal || st.Message() != "ledger unreachable" {
+		t.Fatalf("status = %v", st)
+	}
+	got := FromError(st.Err())
+	if got.Code != orig.Code || got.Msg != orig.Msg || !got.System || got.Chain != "charge<-ledger.Post" {
+		t.Errorf("got %+v", got)
+	}
+	if f := got.Fields(); len(f) != 1 || f["account"] != "42" {
+		t.Errorf("fields = %v", f)
+	}
+}
+
+func TestStatusOrigin(t *testing.T) {
+	prev := baseError.LocalOrigin()
+	defer baseError.SetOrigin(prev)
+	baseError.SetOrigin(baseError.Origin{Service: "ledger", Zone: "eu-2"})
+	st := ToStatus(baseError.System("GRPCX_LEDGER_LOCKED", "locked"))
+
+	baseError.SetOrigin(baseError.Origin{Service: "gateway"})
+	got := FromStatus(st)
+	if o := got.Origin(); o.Service != "ledger" || o.Zone != "eu-2" {
+		t.Errorf("origin = %+v", o)
+	}
+	if _, ok := got.Fields()[MetadataOriginService]; ok {
+		t.Errorf("origin kept as a field: %v", got.Fields())
+	}
+	if o := baseError.Wrap("GRPCX_CHARGE_FAILED", got).Origin(); o.Service != "ledger" {
+		t.Errorf("wrapped origin = %+v", o)
+	}
+	if o := FromStatus(status.New(codes.Internal, "boom")).Origin(); !o.IsZero() {
+		t.Errorf("status without info stamped with %+v", o)
+	}
+}
+
+func TestToStatusCodes(t *testing.T) {
+	baseError.RegisterHTTPStatus("GRPCX_ORDER_NOT_FOUND", http.StatusNotFound)
+	for _, tc := range []struct {
+		err  error
+		want codes.Code
+	}{
+		{baseError.New("GRPCX_ORDER_NOT_FOUND", ""), codes.NotFound},
+		{baseError.New("PERMISSION_DENIED", ""), codes.PermissionDenied},
+		{baseError.New("GRPCX_BAD", ""), codes.InvalidArgument},
+		{errors.New("raw"), codes.Unknown},
+		{nil, codes.OK},
+	} {
+		if got := ToStatus(tc.err).Code(); got != tc.want {
+			t.Errorf("%v: code = %v, want %v", tc.err, got, tc.want)
+		}
+	}
+}
+
+func TestFromStatusWithoutInfo(t *testing.T) {
+	if e := FromStatus(status.New(codes.Unavailable, "db down")); e.Code != "UNAVAILABLE" || !e.System {
+		t.Errorf("got %v system=%v", e, e.System)
+	}
+	if e := FromStatus(status.New(codes.NotFound, "")); e.Code != "NOT_FOUND" || e.System {
+		t.Errorf("got %v system=%v", e, e.System)
+	}
+	if FromStatus(status.New(codes.OK, "")) != nil {
+		t.Error("OK status converted to an error")
+	}
+}
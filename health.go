@@ -0,0 +1,56 @@
+package baseError
+
+import "encoding/json"
+
+type HealthStatus int8
+
+const (
+	HealthServing HealthStatus = iota + 1
+	HealthDegraded
+	HealthNotServing
+)
+
+func (s HealthStatus) String() string {
+	switch s {
+	case HealthServing:
+		return "serving"
+	case HealthDegraded:
+		return "degraded"
+	case HealthNotServing:
+		return "not_serving"
+	}
+	return "unknown"
+}
+
+func (s HealthStatus) MarshalJSON() ([]byte, error) {
+	return json.Marshal(s.String())
+}
+
+type HealthReport struct {
+	Status       HealthStatus     `json:"status"`
+	Dependencies map[string]error `json:"-"`
+}
+
+// CheckHealth aggregates the latest error of each dependency (nil when healthy). A
+// dependency failing with a retryable error below SeverityCritical, or an exhausted
+// budget, degrades the service; any other failure makes it not serving. budget may be nil.
+func CheckHealth(deps map[string]error, budget *Budget) HealthReport {
+	report := HealthReport{Status: HealthServing, Dependencies: make(map[string]error)}
+	if budget != nil && budget.Exhausted() {
+		report.Status = HealthDegraded
+	}
+	for name, err := range deps {
+		if isNil(err) {
+			continue
+		}
+		report.Dependencies[name] = err
+		status := HealthNotServing
+		if IsRetryable(err) && SeverityOf(err) < SeverityCritical {
+			status = HealthDegraded
+		}
+		if status > report.Status {
+			report.Status = status
+		}
+	}
+	return report
+}
@@ -0,0 +1,25 @@
+package baseError
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestCheckHealth(t *testing.T) {
+	if r := CheckHealth(map[string]error{"db": nil}, nil); r.Status != HealthServing {
+		t.Errorf("status = %s", r.Status)
+	}
+	if r := CheckHealth(map[string]error{"db": nil, "cache": context.DeadlineExceeded}, nil); r.Status != HealthDegraded || r.Dependencies["cache"] == nil {
+		t.Errorf("report = %+v", r)
+	}
+	if r := CheckHealth(map[string]error{"db": System("DB_DOWN", ""), "cache": context.DeadlineExceeded}, nil); r.Status != HealthNotServing {
+		t.Errorf("status = %s", r.Status)
+	}
+
+	b := NewBudget(0.99, time.Minute)
+	b.Record(System("DB", ""))
+	if r := CheckHealth(nil, b); r.Status != HealthDegraded {
+		t.Errorf("status = %s", r.Status)
+	}
+}
@@ -0,0 +1,98 @@
+package baseError
+
+import (
+	"container/list"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"sync"
+)
+
+// SiteCount is the number of errors whose stack starts at Site ("file:line").
+type SiteCount struct {
+	Site  string `json:"site"`
+	Count int    `json:"count"`
+}
+
+// Heatmap counts errors per originating call site, keeping at most capacity sites and
+// evicting the least recently hit one. Errors without a stack are not counted. Enable it
+// with AddHook(h.Hook()). The zero value is ready to use and keeps 1024 sites.
+type Heatmap struct {
+	mu       sync.Mutex
+	capacity int
+	order    *list.List
+	sites    map[string]*list.Element
+}
+
+const defaultHeatmapCapacity = 1024
+
+func NewHeatmap(capacity int) *Heatmap {
+	if capacity <= 0 {
+		capacity = defaultHeatmapCapacity
+	}
+	return &Heatmap{capacity: capacity, order: list.New(), sites: make(map[string]*list.Element)}
+}
+
+// lazyInit sets up a zero Heatmap. The caller holds h.mu.
+func (h *Heatmap) lazyInit() {
+	if h.sites != nil {
+		return
+	}
+	if h.capacity <= 0 {
+		h.capacity = defaultHeatmapCapacity
+	}
+	h.order = list.New()
+	h.sites = make(map[string]*list.Element)
+}
+
+func (h *Heatmap) Add(err error) {
+	frames := StackFrames(err)
+	if len(frames) == 0 {
+		return
+	}
+	site := fmt.Sprintf("%s:%d", frames[0].File, frames[0].Line)
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.lazyInit()
+	if el, ok := h.sites[site]; ok {
+		el.Value.(*SiteCount).Count++
+		h.order.MoveToFront(el)
+		return
+	}
+	if h.order.Len() == h.capacity {
+		oldest := h.order.Back()
+		h.order.Remove(oldest)
+		delete(h.sites, oldest.Value.(*SiteCount).Site)
+	}
+	h.sites[site] = h.order.PushFront(&SiteCount{Site: site, Count: 1})
+}
+
+func (h *Heatmap) Hook() Hook {
+	return h.Add
+}
+
+// Top returns the n hottest sites, or all of them if n is zero or less.
+func (h *Heatmap) Top(n int) []SiteCount {
+	h.mu.Lock()
+	h.lazyInit()
+	out := make([]SiteCount, 0, h.order.Len())
+	for el := h.order.Front(); el != nil; el = el.Next() {
+		out = append(out, *el.Value.(*SiteCount))
+	}
+	h.mu.Unlock()
+	sort.Slice(out, func(i, j int) bool {
+		if out[i].Count != out[j].Count {
+			return out[i].Count > out[j].Count
+		}
+		return out[i].Site < out[j].Site
+	})
+	if n > 0 && len(out) > n {
+		out = out[:n]
+	}
+	return out
+}
+
+func (h *Heatmap) MarshalJSON() ([]byte, error) {
+	return json.Marshal(h.Top(0))
+}
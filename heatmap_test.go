@@ -0,0 +1,38 @@
+package baseError
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestHeatmap(t *testing.T) {
+	h := NewHeatmap(2)
+	build := func(code string) *Error { return NewStack(code, "", 4) }
+	hot := func() *Error { return build("HOT") }
+	for i := 0; i < 3; i++ {
+		h.Add(hot())
+	}
+	h.Add(build("COLD"))
+	h.Add(errors.New("no stack"))
+
+	top := h.Top(0)
+	if len(top) != 2 || top[0].Count != 3 || top[1].Count != 1 {
+		t.Fatalf("top = %+v", top)
+	}
+	h.Add(hot())
+	h.Add(Wrap("OUTER", build("INNER")))
+	if top := h.Top(0); len(top) != 2 || top[0].Count != 4 || top[1].Count != 1 || top[1].Site == top[0].Site {
+		t.Errorf("top = %+v", top)
+	}
+}
+
+func TestHeatmapZeroValue(t *testing.T) {
+	var h Heatmap
+	if top := h.Top(0); len(top) != 0 {
+		t.Errorf("top = %+v", top)
+	}
+	h.Add(NewStack("ZERO", "", 4))
+	if top := h.Top(0); len(top) != 1 || top[0].Count != 1 {
+		t.Errorf("top = %+v", top)
+	}
+}
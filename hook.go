@@ -0,0 +1,27 @@
+package baseError
+
+type Hook func(err error)
+
+func AddHook(h Hook) {
+	updateConfig(func(c *Config) {
+		c.Hooks = append(c.Hooks, h)
+	})
+}
+
+// Report hands err to every registered hook unless it is nil or currently suppressed.
+// Hooks get the first report of each fingerprint as a copy marked with the
+// first_occurrence field; see IsFirstOccurrence.
+func Report(err error) {
+	if isNil(err) || Suppressed(err) {
+		return
+	}
+	hooks := cfg().Hooks
+	e := errorOf(err)
+	if e != nil {
+		err = markReported(err, e)
+		hooks = e.cfg().Hooks
+	}
+	for _, h := range hooks {
+		h(err)
+	}
+}
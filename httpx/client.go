@@ -0,0 +1,190 @@
+package httpx
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	baseError "github.com/go-tron/base-error"
+)
+
+const (
+	CodeTransport = "HTTP_TRANSPORT"
+
+	FieldMethod = "http_method"
+	FieldURL    = "http_url"
+	FieldStatus = "http_status"
+)
+
+const maxErrorBody = 64 << 10
+
+// FromHTTPResponse converts a 4xx or 5xx response into *Error, decoding any envelope
+// baseError.DecodeAny recognizes and falling back to a code named after the status. 5xx
+// responses are System errors. 429, 502, 503 and 504 are retryable unless their code
+// has a registered policy. The body is consumed but not closed; other responses,
+// including redirects and 304 Not Modified, return nil.
+func FromHTTPResponse(resp *http.Response) *baseError.Error {
+	if resp.StatusCode < 400 {
+		return nil
+	}
+	body, _ := io.ReadAll(io.LimitReader(resp.Body, maxErrorBody))
+	e, err := baseError.DecodeAny(body)
+	if err != nil || e.Code == "" {
+		msg := strings.TrimSpace(string(body))
+		if msg == "" {
+			msg = http.StatusText(resp.StatusCode)
+		}
+		e = baseError.New(CodeForStatus(resp.StatusCode), msg)
+	}
+	if resp.StatusCode >= 500 {
+		e.WithSystem()
+	}
+	e.WithField(FieldStatus, resp.StatusCode)
+	if retryableStatus(resp.StatusCode) {
+		if _, ok := baseError.DefaultRegistry.Lookup(e.Code); !ok {
+			e.WithRetryable(true)
+		}
+	}
+	return e
+}
+
+// CodeForStatus names an HTTP status as a code, e.g. NOT_FOUND for 404.
+func CodeForStatus(status int) string {
+	if text := http.StatusText(status); text != "" {
+		return strings.ToUpper(strings.ReplaceAll(text, " ", "_"))
+	}
+	return "HTTP_" + strconv.Itoa(status)
+}
+
+// Transport is an http.RoundTripper that turns transport failures into *Error values
+// annotated with the method, URL and duration, and retries up to MaxAttempts times when
+// the request body can be replayed. Failures retryable per baseError.IsRetryable are
+// retried, as are 429, 502, 503 and 504 responses; the error finally returned carries the
+// retry history. As the RoundTripper contract requires, responses are returned as is
+// whatever their status: use Do to convert error statuses too.
+type Transport struct {
+	Base        http.RoundTripper
+	MaxAttempts int
+	Backoff     func(attempt int) time.Duration
+}
+
+func NewTransport(base http.RoundTripper) *Transport {
+	return &Transport{Base: base, MaxAttempts: 1}
+}
+
+func (t *Transport) RoundTrip(req *http.Request) (*http.Response, error) {
+	base := t.Base
+	if base == nil {
+		base = http.DefaultTransport
+	}
+	attempts := t.MaxAttempts
+	if attempts < 1 {
+		attempts = 1
+	}
+	var history baseError.RetryHistory
+	for attempt := 1; ; attempt++ {
+		start := baseError.Now()
+		resp, e := t.roundTrip(base, req)
+		last := attempt == attempts || !replayable(req)
+		if e == nil {
+			if last || !retryableStatus(resp.StatusCode) {
+				return resp, nil
+			}
+			resp.Body.Close()
+		} else {
+			history = history.Add(e)
+			annotate(e, req).
+				WithField(baseError.FieldDuration, baseError.Since(start)).
+				WithAttempt(attempt).
+				WithRetryHistory(history)
+			if last || !baseError.IsRetryable(e) {
+				return nil, e
+			}
+		}
+		if err := t.wait(req, attempt); err != nil {
+			if e == nil {
+				return nil, baseError.Wrap(CodeTransport, err)
+			}
+			return nil, e
+		}
+		if req.GetBody != nil {
+			body, err := req.GetBody()
+			if err != nil {
+				return nil, e
+			}
+			req = req.Clone(req.Context())
+			req.Body = body
+		}
+	}
+}
+
+func (t *Transport) roundTrip(base http.RoundTripper, req *http.Request) (*http.Response, *baseError.Error) {
+	resp, err := base.RoundTrip(req)
+	if err != nil {
+		if e := baseError.WrapNet(err); e != nil {
+			return nil, e
+		}
+		var e *baseError.Error
+		if errors.As(err, &e) {
+			return nil, e.Copy()
+		}
+		return nil, baseError.Wrap(CodeTransport, err)
+	}
+	return resp, nil
+}
+
+// Do sends req with c and converts 4xx and 5xx responses with FromHTTPResponse, closing
+// their body. Errors are annotated with the method and URL.
+func Do(c *http.Client, req *http.Request) (*http.Response, error) {
+	resp, err := c.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	if e := FromHTTPResponse(resp); e != nil {
+		resp.Body.Close()
+		return nil, annotate(e, req)
+	}
+	return resp, nil
+}
+
+func annotate(e *baseError.Error, req *http.Request) *baseError.Error {
+	return e.WithField(FieldMethod, req.Method).WithField(FieldURL, redactURL(req))
+}
+
+// redactURL returns the request URL without password, query or fragment, which may hold
+// tokens and signatures.
+func redactURL(req *http.Request) string {
+	u := *req.URL
+	u.RawQuery, u.ForceQuery, u.Fragment, u.RawFragment = "", false, "", ""
+	return u.Redacted()
+}
+
+func retryableStatus(status int) bool {
+	switch status {
+	case http.StatusTooManyRequests, http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return true
+	}
+	return false
+}
+
+func (t *Transport) wait(req *http.Request, attempt int) error {
+	d := 100 * time.Millisecond << (attempt - 1)
+	if t.Backoff != nil {
+		d = t.Backoff(attempt)
+	}
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return nil
+	case <-req.Context().Done():
+		return req.Context().Err()
+	}
+}
+
+func replayable(req *http.Request) bool {
+	return req.Body == nil || req.Body == http.NoBody || req.GetBody != nil
+}
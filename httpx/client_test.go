@@ -0,0 +1,110 @@
+package httpx
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	baseError "github.com/go-tron/base-error"
+)
+
+func TestFromHTTPResponse(t *testing.T) {
+	resp := &http.Response{StatusCode: 404, Body: io.NopCloser(strings.NewReader(`{"code":"USER_NOT_FOUND","msg":"no such user"}`))}
+	if e := FromHTTPResponse(resp); e.Code != "USER_NOT_FOUND" || e.System || e.Fields()[FieldStatus] != 404 {
+		t.Errorf("got %v %v", e, e.Fields())
+	}
+	resp = &http.Response{StatusCode: 503, Body: io.NopCloser(strings.NewReader("upstream down"))}
+	if e := FromHTTPResponse(resp); e.Code != "SERVICE_UNAVAILABLE" || !e.System || !baseError.IsRetryable(e) {
+		t.Errorf("got %v", e)
+	}
+	for _, status := range []int{204, 302, 304} {
+		if FromHTTPResponse(&http.Response{StatusCode: status, Body: http.NoBody}) != nil {
+			t.Errorf("%d converted", status)
+		}
+	}
+}
+
+func TestTransport(t *testing.T) {
+	var calls int
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		if calls < 3 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.Write([]byte("ok"))
+	}))
+	defer srv.Close()
+
+	tr := NewTransport(nil)
+	tr.MaxAttempts = 3
+	tr.Backoff = func(int) time.Duration { return 0 }
+	client := &http.Client{Transport: tr}
+	resp, err := client.Get(srv.URL)
+	if err != nil || calls != 3 {
+		t.Fatalf("err=%v calls=%d", err, calls)
+	}
+	resp.Body.Close()
+
+	calls = 0
+	tr.MaxAttempts = 2
+	resp, err = client.Get(srv.URL)
+	if err != nil || resp.StatusCode != http.StatusServiceUnavailable || calls != 2 {
+		t.Fatalf("resp=%v err=%v calls=%d", resp, err, calls)
+	}
+	resp.Body.Close()
+
+	calls = 0
+	req, _ := http.NewRequest(http.MethodGet, srv.URL+"/x?token=secret", nil)
+	_, err = Do(client, req)
+	var e *baseError.Error
+	if !errors.As(err, &e) || e.Code != "SERVICE_UNAVAILABLE" || calls != 2 {
+		t.Fatalf("err=%v calls=%d", err, calls)
+	}
+	if f := e.Fields(); f[FieldMethod] != "GET" || f[FieldStatus] != 503 || f[FieldURL] != srv.URL+"/x" {
+		t.Errorf("fields = %v", f)
+	}
+
+	srv.Close()
+	tr.MaxAttempts = 1
+	_, err = client.Get(srv.URL + "?sig=abc")
+	if !errors.As(err, &e) || e.Code != baseError.CodeNetRefused || e.Attempt() != 1 || strings.Contains(e.Fields()[FieldURL].(string), "sig") {
+		t.Errorf("err = %v", err)
+	}
+	if e.Fields()[baseError.FieldDuration] == nil || len(e.RetryHistory()) != 1 {
+		t.Errorf("fields = %v", e.Fields())
+	}
+}
+
+func TestTransportRedirect(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/old" {
+			http.Redirect(w, r, "/new", http.StatusFound)
+			return
+		}
+		if r.Header.Get("If-None-Match") == "v1" {
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		w.Write([]byte("new"))
+	}))
+	defer srv.Close()
+
+	client := &http.Client{Transport: NewTransport(nil)}
+	resp, err := client.Get(srv.URL + "/old")
+	if err != nil || resp.StatusCode != http.StatusOK {
+		t.Fatalf("redirect: resp=%v err=%v", resp, err)
+	}
+	resp.Body.Close()
+	req, _ := http.NewRequest(http.MethodGet, srv.URL+"/new", nil)
+	req.Header.Set("If-None-Match", "v1")
+	resp, err = Do(client, req)
+	if err != nil || resp.StatusCode != http.StatusNotModified {
+		t.Fatalf("conditional: resp=%v err=%v", resp, err)
+	}
+	resp.Body.Close()
+}
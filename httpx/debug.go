@@ -0,0 +1,34 @@
+package httpx
+
+import (
+	"encoding/json"
+	"net/http"
+
+	baseError "github.com/go-tron/base-error"
+)
+
+// DebugHandler serves the error statistics and call site heatmap of the process as JSON.
+// Either may be nil. ?format=text renders the statistics as a table instead. It exposes
+// source locations, so mount it on an internal listener only.
+func DebugHandler(stats *baseError.Stats, heatmap *baseError.Heatmap) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("format") == "text" {
+			w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+			if stats != nil {
+				stats.WriteTable(w)
+			}
+			return
+		}
+		body := struct {
+			Stats   *baseError.Stats   `json:"stats,omitempty"`
+			Heatmap *baseError.Heatmap `json:"heatmap,omitempty"`
+		}{stats, heatmap}
+		buf, err := json.Marshal(body)
+		if err != nil {
+			WriteError(w, err)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json; charset=utf-8")
+		w.Write(buf)
+	})
+}
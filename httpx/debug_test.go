@@ -0,0 +1,28 @@
+package httpx
+
+import (
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	baseError "github.com/go-tron/base-error"
+)
+
+func TestDebugHandler(t *testing.T) {
+	stats, heat := baseError.NewStats(), baseError.NewHeatmap(8)
+	err := baseError.NewStack("TEST_DEBUG", "x", 4)
+	stats.Add(err)
+	heat.Add(err)
+
+	rec := httptest.NewRecorder()
+	DebugHandler(stats, heat).ServeHTTP(rec, httptest.NewRequest("GET", "/debug/errors", nil))
+	if body := rec.Body.String(); !strings.Contains(body, `"code":"TEST_DEBUG"`) || !strings.Contains(body, `"heatmap":[{"site":`) {
+		t.Errorf("body = %s", body)
+	}
+
+	rec = httptest.NewRecorder()
+	DebugHandler(stats, nil).ServeHTTP(rec, httptest.NewRequest("GET", "/debug/errors?format=text", nil))
+	if !strings.HasPrefix(rec.Body.String(), "CODE") {
+		t.Errorf("body = %s", rec.Body.String())
+	}
+}
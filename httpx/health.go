@@ -0,0 +1,35 @@
+package httpx
+
+import (
+	"encoding/json"
+	"net/http"
+
+	baseError "github.com/go-tron/base-error"
+)
+
+// ReadinessHandler serves the report returned by check as JSON. Serving and degraded
+// services answer 200 and not serving ones 503; dependency errors are sanitized.
+func ReadinessHandler(check func(r *http.Request) baseError.HealthReport) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		report := check(r)
+		body := struct {
+			Status       baseError.HealthStatus      `json:"status"`
+			Dependencies map[string]*baseError.Error `json:"dependencies,omitempty"`
+		}{Status: report.Status}
+		for name, err := range report.Dependencies {
+			if body.Dependencies == nil {
+				body.Dependencies = make(map[string]*baseError.Error)
+			}
+			body.Dependencies[name] = baseError.Sanitize(err)
+		}
+
+		status := http.StatusOK
+		if report.Status == baseError.HealthNotServing {
+			status = http.StatusServiceUnavailable
+		}
+		buf, _ := json.Marshal(body)
+		w.Header().Set("Content-Type", "application/json; charset=utf-8")
+		w.WriteHeader(status)
+		w.Write(buf)
+	})
+}
@@ -0,0 +1,32 @@
+package httpx
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	baseError "github.com/go-tron/base-error"
+)
+
+func TestReadinessHandler(t *testing.T) {
+	deps := map[string]error{}
+	h := ReadinessHandler(func(r *http.Request) baseError.HealthReport {
+		return baseError.CheckHealth(deps, nil)
+	})
+
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/ready", nil))
+	if w.Code != http.StatusOK || w.Body.String() != `{"status":"serving"}` {
+		t.Errorf("%d %s", w.Code, w.Body)
+	}
+
+	deps["cache"] = context.DeadlineExceeded
+	deps["db"] = baseError.System("DB_DOWN", "dial tcp 10.0.0.7:5432: connection refused")
+	w = httptest.NewRecorder()
+	h.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/ready", nil))
+	if w.Code != http.StatusServiceUnavailable || !strings.Contains(w.Body.String(), `"status":"not_serving"`) || strings.Contains(w.Body.String(), "10.0.0.7") {
+		t.Errorf("%d %s", w.Code, w.Body)
+	}
+}
@@ -0,0 +1,28 @@
+// Package httpx renders baseError values as HTTP responses.
+package httpx
+
+import (
+	"encoding/json"
+	"net/http"
+
+	baseError "github.com/go-tron/base-error"
+)
+
+// Status returns the HTTP status for err; see baseError.HTTPStatus.
+func Status(err error) int {
+	return baseError.HTTPStatus(err)
+}
+
+// WriteError writes err as a JSON {"code","msg"} envelope. Only the sanitized form of
+// System and foreign errors is ever written. Nothing is written for a nil err, a nil
+// *baseError.Error included.
+func WriteError(w http.ResponseWriter, err error) {
+	if e, ok := err.(*baseError.Error); err == nil || ok && e == nil {
+		return
+	}
+	status := Status(err)
+	buf, _ := json.Marshal(baseError.Sanitize(err))
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.WriteHeader(status)
+	w.Write(buf)
+}
@@ -0,0 +1,32 @@
+package httpx
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	baseError "github.com/go-tron/base-error"
+)
+
+func TestWriteError(t *testing.T) {
+	w := httptest.NewRecorder()
+	WriteError(w, baseError.New("USER_NOT_FOUND", "user not found"))
+	if w.Code != http.StatusBadRequest || w.Body.String() != `{"code":"USER_NOT_FOUND","msg":"user not found"}` {
+		t.Errorf("%d %s", w.Code, w.Body)
+	}
+
+	w = httptest.NewRecorder()
+	WriteError(w, baseError.Wrap("DB_QUERY", errors.New("pq: syntax error at or near \"FROM\"")))
+	if w.Code != http.StatusInternalServerError || strings.Contains(w.Body.String(), "pq:") || !strings.Contains(w.Body.String(), `"ref"`) {
+		t.Errorf("%d %s", w.Code, w.Body)
+	}
+
+	w = httptest.NewRecorder()
+	var nilErr *baseError.Error
+	WriteError(w, nilErr)
+	if w.Body.Len() != 0 || w.Header().Get("Content-Type") != "" {
+		t.Errorf("typed nil wrote %d %s", w.Code, w.Body)
+	}
+}
@@ -0,0 +1,51 @@
+package httpx
+
+import (
+	"log"
+	"net/http"
+
+	baseError "github.com/go-tron/base-error"
+)
+
+// LogError logs errors written by HandlerFunc and Middleware whose level is at least
+// LevelWarn. It prints %+v, which includes stacks and fields, through the standard log
+// package; replace it to use another logger.
+var LogError = func(r *http.Request, err error) {
+	log.Printf("%s %s: %+v", r.Method, r.URL.Path, err)
+}
+
+// HandlerFunc is an http.Handler that returns its error. A non-nil error is logged and
+// written with WriteError, so System errors reach the client sanitized only.
+type HandlerFunc func(w http.ResponseWriter, r *http.Request) error
+
+func (f HandlerFunc) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if err := f(w, r); err != nil {
+		handleError(w, r, err)
+	}
+}
+
+// Middleware recovers panics in next and answers them like HandlerFunc answers errors.
+// http.ErrAbortHandler is re-panicked so net/http can abort the response.
+func Middleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		defer func() {
+			if v := recover(); v != nil {
+				if v == http.ErrAbortHandler {
+					panic(v)
+				}
+				handleError(w, r, baseError.FromPanic(v))
+			}
+		}()
+		next.ServeHTTP(w, r)
+	})
+}
+
+func handleError(w http.ResponseWriter, r *http.Request, err error) {
+	if e, ok := err.(*baseError.Error); ok && e == nil {
+		return
+	}
+	if baseError.ShouldLog(err, baseError.LevelWarn) {
+		LogError(r, err)
+	}
+	WriteError(w, err)
+}
@@ -0,0 +1,63 @@
+package httpx
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	baseError "github.com/go-tron/base-error"
+)
+
+func TestHandlerFunc(t *testing.T) {
+	var logged []string
+	prev := LogError
+	defer func() { LogError = prev }()
+	LogError = func(r *http.Request, err error) { logged = append(logged, baseError.CodeOf(err)) }
+
+	h := Middleware(HandlerFunc(func(w http.ResponseWriter, r *http.Request) error {
+		switch r.URL.Path {
+		case "/missing":
+			return baseError.New("HTTPX_ORDER_MISSING", "order missing")
+		case "/db":
+			return baseError.Wrap("HTTPX_DB", errors.New("password=hunter2"))
+		case "/panic":
+			panic("boom")
+		}
+		w.Write([]byte("ok"))
+		return nil
+	}))
+	serve := func(path string) *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		h.ServeHTTP(w, httptest.NewRequest(http.MethodGet, path, nil))
+		return w
+	}
+
+	if w := serve("/missing"); w.Code != http.StatusBadRequest || !strings.Contains(w.Body.String(), "order missing") {
+		t.Errorf("missing: %d %s", w.Code, w.Body)
+	}
+	if w := serve("/db"); w.Code != http.StatusInternalServerError || strings.Contains(w.Body.String(), "hunter2") {
+		t.Errorf("db: %d %s", w.Code, w.Body)
+	}
+	if w := serve("/panic"); w.Code != http.StatusInternalServerError || strings.Contains(w.Body.String(), "boom") {
+		t.Errorf("panic: %d %s", w.Code, w.Body)
+	}
+	if w := serve("/"); w.Code != http.StatusOK || w.Body.String() != "ok" {
+		t.Errorf("ok: %d %s", w.Code, w.Body)
+	}
+	if strings.Join(logged, ",") != "HTTPX_ORDER_MISSING,HTTPX_DB,PANIC" {
+		t.Errorf("logged = %v", logged)
+	}
+}
+
+func TestMiddlewareAbort(t *testing.T) {
+	defer func() {
+		if r := recover(); r != http.ErrAbortHandler {
+			t.Errorf("recovered %v", r)
+		}
+	}()
+	Middleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		panic(http.ErrAbortHandler)
+	})).ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/", nil))
+}
@@ -0,0 +1,68 @@
+package baseError
+
+import (
+	"strings"
+)
+
+// joined holds the causes of a Join. It is what *Error.Unwrap returns for them, so
+// errors.Is and errors.As see every cause through its Unwrap() []error.
+type joined struct {
+	errs []error
+}
+
+func (j *joined) Error() string {
+	msgs := make([]string, len(j.errs))
+	for i, err := range j.errs {
+		msgs[i] = err.Error()
+	}
+	return strings.Join(msgs, "; ")
+}
+
+func (j *joined) Unwrap() []error {
+	return j.errs
+}
+
+// Join reports several independent failures, such as those of a fan-out, under one System
+// error with code. Nil errors are dropped; Join returns nil if none remain and behaves
+// like Wrap for a single error.
+//
+// That nil is a nil *Error: returned as an error it compares non-nil. Functions returning
+// error should use JoinErr instead.
+func Join(code string, errs ...error) *Error {
+	var kept []error
+	for _, err := range errs {
+		if err != nil {
+			kept = append(kept, err)
+		}
+	}
+	switch len(kept) {
+	case 0:
+		return nil
+	case 1:
+		return Wrap(code, kept[0])
+	}
+	j := &joined{errs: kept}
+	return created(&Error{Code: code, Msg: j.Error(), System: true, cause: j})
+}
+
+// JoinErr is Join returning an untyped nil when every error is nil.
+func JoinErr(code string, errs ...error) error {
+	if e := Join(code, errs...); e != nil {
+		return e
+	}
+	return nil
+}
+
+// Causes returns the errors b wraps: every error of a Join, or its single cause.
+func (b *Error) Causes() []error {
+	if b == nil {
+		return nil
+	}
+	if j, ok := b.cause.(*joined); ok {
+		return append([]error(nil), j.errs...)
+	}
+	if b.cause != nil {
+		return []error{b.cause}
+	}
+	return nil
+}
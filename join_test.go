@@ -0,0 +1,46 @@
+package baseError
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"testing"
+)
+
+func TestJoin(t *testing.T) {
+	a := NewStack("SHARD_A", "shard a down", 4)
+	b := NewStack("SHARD_B", "shard b down", 4)
+	err := Join("FANOUT_FAILED", a, nil, b, io.EOF)
+	if err.Msg != "[SHARD_A] shard a down; [SHARD_B] shard b down; EOF" || !err.System {
+		t.Errorf("got %v", err)
+	}
+	if !errors.Is(err, CodeError("SHARD_B")) || !errors.Is(err, io.EOF) {
+		t.Error("causes unreachable through errors.Is")
+	}
+	var target *Error
+	if !errors.As(err.Unwrap(), &target) || target.Code != "SHARD_A" {
+		t.Errorf("as = %v", target)
+	}
+	if n := len(err.Causes()); n != 3 {
+		t.Errorf("causes = %d", n)
+	}
+
+	out := fmt.Sprintf("%+v", err)
+	if !strings.Contains(out, "---cause 1/3---\n[SHARD_A]") || !strings.Contains(out, "---cause 3/3---\nEOF") || strings.Count(out, "\n\t") < 4 {
+		t.Errorf("format:\n%s", out)
+	}
+
+	if Join("X", nil, nil) != nil {
+		t.Error("expected nil")
+	}
+	if err := JoinErr("X", nil, nil); err != nil {
+		t.Errorf("JoinErr = %#v", err)
+	}
+	if err := JoinErr("X", io.EOF, io.ErrUnexpectedEOF); CodeOf(err) != "X" {
+		t.Errorf("JoinErr = %v", err)
+	}
+	if one := Join("X", io.EOF); one.Unwrap() != io.EOF {
+		t.Errorf("single cause = %v", one.Unwrap())
+	}
+}
@@ -0,0 +1,130 @@
+package baseError
+
+import (
+	"encoding/json"
+	"fmt"
+	"runtime"
+
+	"github.com/pkg/errors"
+)
+
+const maxCauseDepth = 32
+
+type causeNode struct {
+	Code   string      `json:"code,omitempty"`
+	Type   string      `json:"type,omitempty"`
+	Msg    string      `json:"msg"`
+	Frame  string      `json:"frame,omitempty"`
+	Causes []causeNode `json:"causes,omitempty"`
+}
+
+type envelope struct {
+	Code      string                 `json:"code"`
+	Msg       string                 `json:"msg"`
+	Details   []string               `json:"details,omitempty"`
+	Ref       string                 `json:"ref,omitempty"`
+	Origin    *Origin                `json:"origin,omitempty"`
+	Fields    map[string]interface{} `json:"fields,omitempty"`
+	Frame     string                 `json:"frame,omitempty"`
+	Causes    []causeNode            `json:"causes,omitempty"`
+	Truncated bool                   `json:"truncated,omitempty"`
+}
+
+func (b *Error) MarshalJSON() ([]byte, error) {
+	if b == nil {
+		return []byte("null"), nil
+	}
+	return b.marshal(b.cfg().MaxEnvelopeSize)
+}
+
+func (b *Error) envelope() envelope {
+	out := envelope{Code: b.Code, Msg: b.Msg, Details: b.safeDetails}
+	out.Ref, _ = b.fields[FieldReference].(string)
+	if !b.origin.IsZero() {
+		out.Origin = &b.origin
+	}
+	out.Fields = b.jsonFields()
+	if b.cfg().JSONCauseTree {
+		out.Frame = topFrame(b)
+		out.Causes = causeNodes(b, 0)
+	}
+	return out
+}
+
+// jsonFields returns the fields other than ref, with values JSON can't encode replaced
+// by their fmt form.
+func (b *Error) jsonFields() map[string]interface{} {
+	var out map[string]interface{}
+	for k, v := range b.fields {
+		if k == FieldReference {
+			continue
+		}
+		if out == nil {
+			out = make(map[string]interface{}, len(b.fields))
+		}
+		v = fieldValue(v)
+		if _, err := json.Marshal(v); err != nil {
+			v = fmt.Sprint(v)
+		}
+		out[k] = v
+	}
+	return out
+}
+
+func causeNodes(err error, depth int) []causeNode {
+	if depth >= maxCauseDepth {
+		return nil
+	}
+	var nodes []causeNode
+	for _, cause := range causes(err) {
+		node := causeNode{Msg: cause.Error(), Frame: topFrame(cause)}
+		if e, ok := cause.(*Error); ok {
+			node.Code = e.Code
+			node.Msg = e.Msg
+		} else {
+			node.Type = fmt.Sprintf("%T", cause)
+		}
+		node.Causes = causeNodes(cause, depth+1)
+		nodes = append(nodes, node)
+	}
+	return nodes
+}
+
+// causes returns the errors directly wrapped by err, understanding both the standard
+// library's Unwrap conventions and pkg/errors' Cause.
+func causes(err error) []error {
+	switch x := err.(type) {
+	case *Error:
+		return x.Causes()
+	case interface{ Unwrap() []error }:
+		return x.Unwrap()
+	case interface{ Unwrap() error }:
+		if c := x.Unwrap(); c != nil {
+			return []error{c}
+		}
+	case interface{ Cause() error }:
+		if c := x.Cause(); c != nil {
+			return []error{c}
+		}
+	}
+	return nil
+}
+
+func topFrame(err error) string {
+	var pc uintptr
+	switch x := err.(type) {
+	case *Error:
+		if x.stack != nil && len(*x.stack) > 0 {
+			pc = (*x.stack)[0]
+		}
+	case interface{ StackTrace() errors.StackTrace }:
+		if st := x.StackTrace(); len(st) > 0 {
+			pc = uintptr(st[0])
+		}
+	}
+	if pc == 0 {
+		return ""
+	}
+	f, _ := runtime.CallersFrames([]uintptr{pc}).Next()
+	return fmt.Sprintf("%s %s:%d", f.Function, f.File, f.Line)
+}
@@ -0,0 +1,56 @@
+package baseError
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/pkg/errors"
+)
+
+func TestMarshalJSON(t *testing.T) {
+	err := WrapStack("SAVE_FAILED", fmt.Errorf("tx: %w", errors.New("disk full")), 5)
+
+	buf, _ := json.Marshal(err)
+	if string(buf) != `{"code":"SAVE_FAILED","msg":"tx: disk full"}` {
+		t.Errorf("got %s", buf)
+	}
+
+	prev := CurrentConfig()
+	defer Configure(prev)
+	c := CurrentConfig()
+	c.JSONCauseTree = true
+	Configure(c)
+	buf, _ = json.Marshal(err)
+
+	var tree struct {
+		Frame  string
+		Causes []causeNode
+	}
+	json.Unmarshal(buf, &tree)
+	if tree.Frame == "" {
+		t.Errorf("frame = %s", tree.Frame)
+	}
+	if len(tree.Causes) != 1 || tree.Causes[0].Type != "*fmt.wrapError" || len(tree.Causes[0].Causes) != 1 {
+		t.Fatalf("got %s", buf)
+	}
+	if leaf := tree.Causes[0].Causes[0]; leaf.Msg != "disk full" || !strings.Contains(leaf.Frame, "TestMarshalJSON") {
+		t.Errorf("leaf = %+v", leaf)
+	}
+}
+
+func TestFieldsRendering(t *testing.T) {
+	err := New("ORDER_INVALID", "bad order").WithFields(map[string]interface{}{
+		"order_id": 7,
+		"request":  "req-1",
+		"callback": func() {},
+	})
+	buf, _ := json.Marshal(err)
+	if !strings.Contains(string(buf), `"fields":{"callback":"0x`) || !strings.Contains(string(buf), `"order_id":7,"request":"req-1"}`) {
+		t.Errorf("json = %s", buf)
+	}
+	if out := fmt.Sprintf("%+v", err); !strings.HasSuffix(out, "\n---fields---\ncallback="+fmt.Sprint(err.Fields()["callback"])+"\norder_id=7\nrequest=req-1") {
+		t.Errorf("format:\n%s", out)
+	}
+}
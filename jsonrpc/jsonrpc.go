@@ -0,0 +1,123 @@
+// Package jsonrpc maps baseError values to and from JSON-RPC 2.0 error objects.
+package jsonrpc
+
+import (
+	"errors"
+	"strconv"
+	"sync"
+
+	baseError "github.com/go-tron/base-error"
+)
+
+// Codes reserved by the JSON-RPC 2.0 specification.
+const (
+	ParseError     = -32700
+	InvalidRequest = -32600
+	MethodNotFound = -32601
+	InvalidParams  = -32602
+	InternalError  = -32603
+	// ServerError is the first of the implementation-defined server error codes
+	// -32000 to -32099, used for business errors without a registered number.
+	ServerError = -32000
+)
+
+var reserved = map[int]string{
+	ParseError:     "PARSE_ERROR",
+	InvalidRequest: "INVALID_REQUEST",
+	MethodNotFound: "METHOD_NOT_FOUND",
+	InvalidParams:  "INVALID_PARAMS",
+	InternalError:  "INTERNAL_ERROR",
+}
+
+var table struct {
+	sync.RWMutex
+	numbers map[string]int
+	codes   map[int]string
+}
+
+// RegisterCode maps code to the integer n in both directions.
+func RegisterCode(code string, n int) {
+	table.Lock()
+	defer table.Unlock()
+	if table.numbers == nil {
+		table.numbers = make(map[string]int)
+		table.codes = make(map[int]string)
+	}
+	table.numbers[code] = n
+	table.codes[n] = code
+}
+
+// Error is a JSON-RPC 2.0 error object.
+type Error struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+	Data    *Data  `json:"data,omitempty"`
+}
+
+// Data carries the baseError code, reference and fields of an Error.
+type Data struct {
+	Code   string                 `json:"code,omitempty"`
+	Ref    string                 `json:"ref,omitempty"`
+	Fields map[string]interface{} `json:"fields,omitempty"`
+}
+
+func (e *Error) Error() string {
+	return "jsonrpc " + strconv.Itoa(e.Code) + ": " + e.Message
+}
+
+// ToJSONRPC converts the sanitized form of err to an error object. The number is the one
+// registered for the code, else InternalError for System and foreign errors and
+// ServerError otherwise. Data holds the code, the reference of System errors and the
+// fields baseError.ToProblem exposes.
+func ToJSONRPC(err error) *Error {
+	if err == nil {
+		return nil
+	}
+	var e *baseError.Error
+	if errors.As(err, &e) && e == nil {
+		return nil
+	}
+	p := baseError.ToProblem(err)
+	out := &Error{Code: ServerError, Message: p.Detail, Data: &Data{Code: p.Code, Ref: p.Instance, Fields: p.Extensions}}
+	if e == nil || e.System {
+		out.Code = InternalError
+	}
+	table.RLock()
+	defer table.RUnlock()
+	if n, ok := table.numbers[p.Code]; ok {
+		out.Code = n
+	}
+	return out
+}
+
+// FromJSONRPC converts an error object received by a client. The code is taken from Data,
+// else from the registered codes, else named after the number. InternalError, reserved
+// and server error numbers without Data are System errors.
+func FromJSONRPC(obj *Error) *baseError.Error {
+	if obj == nil {
+		return nil
+	}
+	if obj.Data != nil && obj.Data.Code != "" {
+		e := baseError.New(obj.Data.Code, obj.Message).WithFields(obj.Data.Fields)
+		if obj.Data.Ref != "" {
+			e.WithField(baseError.FieldReference, obj.Data.Ref)
+		}
+		if obj.Code == InternalError {
+			e.WithSystem()
+		}
+		return e
+	}
+	table.RLock()
+	code, ok := table.codes[obj.Code]
+	table.RUnlock()
+	if ok {
+		return baseError.New(code, obj.Message)
+	}
+	if name, ok := reserved[obj.Code]; ok {
+		return baseError.System(name, obj.Message)
+	}
+	if obj.Code <= ServerError && obj.Code > ServerError-100 {
+		return baseError.System("SERVER_ERROR", obj.Message).WithField("jsonrpc_code", obj.Code)
+	}
+	return baseError.New("JSONRPC_"+strconv.Itoa(obj.Code), obj.Message)
+}
@@ -0,0 +1,55 @@
+package jsonrpc
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+
+	baseError "github.com/go-tron/base-error"
+)
+
+func TestRoundTrip(t *testing.T) {
+	RegisterCode("JSONRPC_NONCE_TOO_LOW", -32010)
+
+	obj := ToJSONRPC(baseError.New("JSONRPC_NONCE_TOO_LOW", "nonce too low").WithField("nonce", 7))
+	buf, _ := json.Marshal(obj)
+	if string(buf) != `{"code":-32010,"message":"nonce too low","data":{"code":"JSONRPC_NONCE_TOO_LOW","fields":{"nonce":7}}}` {
+		t.Errorf("json = %s", buf)
+	}
+	var back Error
+	json.Unmarshal(buf, &back)
+	if e := FromJSONRPC(&back); e.Code != "JSONRPC_NONCE_TOO_LOW" || e.System || e.Fields()["nonce"] != float64(7) {
+		t.Errorf("back = %v %v", e, e.Fields())
+	}
+
+	obj = ToJSONRPC(errors.New("disk on fire"))
+	if obj.Code != InternalError || obj.Message == "disk on fire" || obj.Data.Ref == "" {
+		t.Errorf("foreign = %+v", obj)
+	}
+	if e := FromJSONRPC(obj); !e.System || e.Fields()[baseError.FieldReference] != obj.Data.Ref {
+		t.Errorf("foreign back = %v", e)
+	}
+	if ToJSONRPC(baseError.New("JSONRPC_OTHER", "")).Code != ServerError {
+		t.Error("unregistered business error not a server error")
+	}
+}
+
+func TestFromForeignNode(t *testing.T) {
+	for _, tc := range []struct {
+		obj    Error
+		code   string
+		system bool
+	}{
+		{Error{Code: MethodNotFound, Message: "no such method"}, "METHOD_NOT_FOUND", true},
+		{Error{Code: -32010, Message: "nonce too low"}, "JSONRPC_NONCE_TOO_LOW", false},
+		{Error{Code: -32042, Message: "execution reverted"}, "SERVER_ERROR", true},
+		{Error{Code: 3, Message: "reverted"}, "JSONRPC_3", false},
+	} {
+		if e := FromJSONRPC(&tc.obj); e.Code != tc.code || e.System != tc.system {
+			t.Errorf("%+v: got %v system=%v", tc.obj, e, e.System)
+		}
+	}
+	if FromJSONRPC(nil) != nil || ToJSONRPC(nil) != nil {
+		t.Error("nil converted")
+	}
+}
@@ -0,0 +1,121 @@
+package baseError
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// LocalizationReport lists, per locale, the codes with a message template that have no
+// message registered for that locale.
+type LocalizationReport struct {
+	Locales []string
+	Missing map[string][]string
+}
+
+func (r LocalizationReport) Complete() bool {
+	for _, codes := range r.Missing {
+		if len(codes) > 0 {
+			return false
+		}
+	}
+	return true
+}
+
+func (r LocalizationReport) String() string {
+	var b strings.Builder
+	for _, locale := range r.Locales {
+		codes := r.Missing[locale]
+		if len(codes) == 0 {
+			continue
+		}
+		fmt.Fprintf(&b, "%s: missing %d: %s\n", locale, len(codes), strings.Join(codes, ", "))
+	}
+	return b.String()
+}
+
+// Localization reports the translation gaps of r for locales. If none are given, every
+// locale with at least one message is checked. Codes count as translatable once they
+// have a template or any message.
+func (r *Registry) Localization(locales ...string) LocalizationReport {
+	infos := make([]CodeInfo, 0)
+	seen := make(map[string]bool)
+	for _, code := range r.Codes() {
+		info, _ := r.Lookup(code)
+		if info.Template == "" && len(info.Messages) == 0 {
+			continue
+		}
+		infos = append(infos, info)
+		for locale := range info.Messages {
+			seen[locale] = true
+		}
+	}
+	if len(locales) == 0 {
+		for locale := range seen {
+			locales = append(locales, locale)
+		}
+	}
+	locales = append([]string(nil), locales...)
+	sort.Strings(locales)
+
+	report := LocalizationReport{Locales: locales, Missing: make(map[string][]string, len(locales))}
+	for _, locale := range locales {
+		for _, info := range infos {
+			if _, ok := info.Messages[locale]; !ok {
+				report.Missing[locale] = append(report.Missing[locale], info.Code)
+			}
+		}
+	}
+	return report
+}
+
+// Message returns the template of code for locale, trying the locale, then its base
+// language, like Title.
+func (r *Registry) Message(code string, locale string) (string, bool) {
+	info, ok := r.Lookup(code)
+	if !ok {
+		return "", false
+	}
+	for _, l := range []string{locale, baseLanguage(locale)} {
+		if m, ok := info.Messages[l]; ok {
+			return m, true
+		}
+	}
+	return "", false
+}
+
+// WithArgs records the values the message placeholders are filled with when the error is
+// localized. Factories record their arguments themselves. The arguments live as long as
+// the error and are shared with the copies made by Copy, ReplaceCode and NegativeCache
+// hits, so don't pass values that are large or mutated later.
+func (b *Error) WithArgs(args ...interface{}) *Error {
+	b.args = args
+	return b
+}
+
+// Localize renders the message registered for the code and locale with the error's
+// arguments, or returns Msg when there is none.
+func (b *Error) Localize(locale string) string {
+	if b == nil {
+		return ""
+	}
+	tmpl, ok := b.registry().Message(b.Code, locale)
+	if !ok {
+		return b.Msg
+	}
+	_, format := factoryFormat(b.Code, tmpl)
+	msg, _ := format(b.args...)
+	return msg
+}
+
+// LocalizedMsg is Localize for the first *Error in err's chain; foreign errors render
+// as err.Error().
+func LocalizedMsg(err error, locale string) string {
+	if isNil(err) {
+		return ""
+	}
+	if e := errorOf(err); e != nil {
+		return e.Localize(locale)
+	}
+	return err.Error()
+}
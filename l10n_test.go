@@ -0,0 +1,58 @@
+package baseError
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestLocalization(t *testing.T) {
+	r := NewRegistry()
+	r.SetTemplate("ORDER_NOT_FOUND", "order {} not found")
+	r.SetMessage("ORDER_NOT_FOUND", "zh-CN", "订单 {} 不存在")
+	r.SetMessage("ORDER_NOT_FOUND", "de", "Bestellung {} nicht gefunden")
+	r.SetTemplate("CART_EMPTY", "cart is empty")
+	r.SetMessage("CART_EMPTY", "de", "Warenkorb ist leer")
+	r.SetLogLevel("UNTRANSLATED_INTERNAL", LevelDebug)
+
+	report := r.Localization()
+	if report.Complete() || len(report.Locales) != 2 || len(report.Missing["de"]) != 0 {
+		t.Fatalf("report = %+v", report)
+	}
+	if m := report.Missing["zh-CN"]; len(m) != 1 || m[0] != "CART_EMPTY" {
+		t.Errorf("zh-CN missing = %v", m)
+	}
+	if s := r.Localization("fr").String(); s != "fr: missing 2: CART_EMPTY, ORDER_NOT_FOUND\n" {
+		t.Errorf("report = %q", s)
+	}
+	if !r.Localization("de").Complete() {
+		t.Error("de should be complete")
+	}
+}
+
+func TestLocalize(t *testing.T) {
+	RegisterMessage("L10N_ORDER_NOT_FOUND", "zh-CN", "订单 {} 不存在")
+	RegisterMessage("L10N_ORDER_NOT_FOUND", "en", "order {} does not exist")
+	notFound := Factory("L10N_ORDER_NOT_FOUND", "order {} not found")
+
+	e := notFound(42)
+	cases := map[string]string{
+		"zh-CN": "订单 42 不存在",
+		"en-US": "order 42 does not exist",
+		"fr":    "order 42 not found",
+		"":      "order 42 not found",
+	}
+	for locale, want := range cases {
+		if got := e.Localize(locale); got != want {
+			t.Errorf("Localize(%q) = %q, want %q", locale, got, want)
+		}
+	}
+	if got := LocalizedMsg(fmt.Errorf("load: %w", e), "zh-CN"); got != "订单 42 不存在" {
+		t.Errorf("LocalizedMsg = %q", got)
+	}
+	if got := New("L10N_ORDER_NOT_FOUND", "").WithArgs(7).Localize("zh-CN"); got != "订单 7 不存在" {
+		t.Errorf("WithArgs = %q", got)
+	}
+	if p := LocalizedProblem(e, "zh-CN"); p.Detail != "订单 42 不存在" {
+		t.Errorf("detail = %q", p.Detail)
+	}
+}
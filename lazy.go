@@ -0,0 +1,31 @@
+package baseError
+
+import "sync"
+
+type lazyField struct {
+	once sync.Once
+	fn   func() interface{}
+	v    interface{}
+}
+
+func (l *lazyField) value() interface{} {
+	l.once.Do(func() {
+		l.v = l.fn()
+	})
+	return l.v
+}
+
+// fieldValue resolves lazy field values and returns others unchanged.
+func fieldValue(v interface{}) interface{} {
+	if l, ok := v.(*lazyField); ok {
+		return l.value()
+	}
+	return v
+}
+
+// WithLazyField sets a field whose value is computed by fn the first time the error's
+// fields are read: by Fields, serialization, %+v formatting or a logging integration.
+// Errors that are handled silently never call fn.
+func (b *Error) WithLazyField(key string, fn func() interface{}) *Error {
+	return b.WithField(key, &lazyField{fn: fn})
+}
@@ -0,0 +1,37 @@
+package baseError
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func TestWithLazyField(t *testing.T) {
+	calls := 0
+	e := New("QUEUE_FULL", "queue full").WithLazyField("depth", func() interface{} {
+		calls++
+		return 128
+	})
+	if calls != 0 {
+		t.Fatal("evaluated on construction")
+	}
+	_ = e.Error()
+	if calls != 0 {
+		t.Fatal("evaluated by Error")
+	}
+
+	if got := e.Fields()["depth"]; got != 128 {
+		t.Errorf("Fields = %v", got)
+	}
+	buf, _ := json.Marshal(e)
+	if !strings.Contains(string(buf), `"depth":128`) {
+		t.Errorf("json = %s", buf)
+	}
+	if s := fmt.Sprintf("%+v", e); !strings.Contains(s, "depth=128") {
+		t.Errorf("format = %s", s)
+	}
+	if calls != 1 {
+		t.Errorf("calls = %d, want 1", calls)
+	}
+}
@@ -0,0 +1,133 @@
+package baseError
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// LintTemplate checks a message template for unbalanced braces, placeholders that are
+// neither {} nor {name}, printf verbs, which Factory would not substitute, and for any of
+// the banned words.
+func LintTemplate(template string, banned ...string) error {
+	var problems []string
+	for rest := template; rest != ""; {
+		open, close := strings.IndexByte(rest, '{'), strings.IndexByte(rest, '}')
+		switch {
+		case open < 0 && close < 0:
+			rest = ""
+		case open < 0 || (close >= 0 && close < open):
+			problems = append(problems, "unmatched '}'")
+			rest = rest[close+1:]
+		case close < 0:
+			problems = append(problems, "unclosed '{'")
+			rest = ""
+		default:
+			if name := rest[open+1 : close]; name != "" && !isPlaceholderName(name) {
+				problems = append(problems, fmt.Sprintf("invalid placeholder %q", "{"+name+"}"))
+			}
+			rest = rest[close+1:]
+		}
+	}
+	for i := 0; i+1 < len(template); i++ {
+		if template[i] != '%' {
+			continue
+		}
+		if template[i+1] != '%' {
+			problems = append(problems, fmt.Sprintf("stray verb %q", template[i:i+2]))
+		}
+		i++
+	}
+	lower := strings.ToLower(template)
+	for _, w := range banned {
+		if w != "" && strings.Contains(lower, strings.ToLower(w)) {
+			problems = append(problems, fmt.Sprintf("banned word %q", w))
+		}
+	}
+	if len(problems) == 0 {
+		return nil
+	}
+	return errors.New(strings.Join(dedupe(problems), ", "))
+}
+
+func dedupe(s []string) []string {
+	seen := make(map[string]bool, len(s))
+	out := s[:0]
+	for _, v := range s {
+		if !seen[v] {
+			seen[v] = true
+			out = append(out, v)
+		}
+	}
+	return out
+}
+
+// Validate lints every template in List, including those declared by factories and
+// Register, and every locale message, and checks that all the messages of a code have
+// the same placeholders as its template: the same {name}s, in any order, and the same
+// number of {}. It is meant to run in CI from a test.
+func (r *Registry) Validate(banned ...string) error {
+	var errs []error
+	for _, reg := range r.List() {
+		code := reg.Code
+		info, _ := r.Lookup(code)
+		if reg.Template == "" && len(info.Messages) == 0 {
+			continue
+		}
+		want, known := "", false
+		if reg.Template != "" {
+			want, known = placeholderSet(reg.Template), true
+			if err := LintTemplate(reg.Template, banned...); err != nil {
+				errs = append(errs, fmt.Errorf("baseError: code %s: template: %w", code, err))
+			}
+		}
+		locales := make([]string, 0, len(info.Messages))
+		for locale := range info.Messages {
+			locales = append(locales, locale)
+		}
+		sort.Strings(locales)
+		for _, locale := range locales {
+			msg := info.Messages[locale]
+			if err := LintTemplate(msg, banned...); err != nil {
+				errs = append(errs, fmt.Errorf("baseError: code %s: locale %s: %w", code, locale, err))
+			}
+			got := placeholderSet(msg)
+			if !known {
+				want, known = got, true
+			} else if got != want {
+				errs = append(errs, fmt.Errorf("baseError: code %s: locale %s has placeholders %s, want %s", code, locale, got, want))
+			}
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// RegistryValidate validates DefaultRegistry. The templatecheck analyzer, in its own
+// module, runs LintTemplate on constant templates at vet time.
+func RegistryValidate(banned ...string) error {
+	return DefaultRegistry.Validate(banned...)
+}
+
+// placeholderSet describes the placeholders of template independently of their order,
+// e.g. "{} {id} {region}", or "none".
+func placeholderSet(template string) string {
+	_, names := parseNamed(template)
+	if names == nil {
+		names = make([]string, strings.Count(template, "{}"))
+	}
+	seen := make(map[string]bool, len(names))
+	var set []string
+	for _, name := range names {
+		if name != "" && seen[name] {
+			continue
+		}
+		seen[name] = true
+		set = append(set, "{"+name+"}")
+	}
+	if len(set) == 0 {
+		return "none"
+	}
+	sort.Strings(set)
+	return strings.Join(set, " ")
+}
@@ -0,0 +1,59 @@
+package baseError
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestLintTemplate(t *testing.T) {
+	if err := LintTemplate("{} not found in {region}, 100%% sure"); err != nil {
+		t.Error(err)
+	}
+	for tmpl, want := range map[string]string{
+		"user {the id} gone": `invalid placeholder "{the id}"`,
+		"user { not found":   "unclosed '{'",
+		"done} now":          "unmatched '}'",
+		"user %v not found":  `stray verb "%v"`,
+		"oops, stupid input": `banned word "stupid"`,
+	} {
+		err := LintTemplate(tmpl, "stupid")
+		if err == nil || !strings.Contains(err.Error(), want) {
+			t.Errorf("LintTemplate(%q) = %v, want %s", tmpl, err, want)
+		}
+	}
+}
+
+func TestRegistryValidate(t *testing.T) {
+	r := NewRegistry()
+	r.SetTemplate("ORDER_NOT_FOUND", "order {} not found")
+	r.SetMessage("ORDER_NOT_FOUND", "de", "Bestellung {} nicht gefunden")
+	if err := r.Validate(); err != nil {
+		t.Fatal(err)
+	}
+	r.SetMessage("ORDER_NOT_FOUND", "fr", "commande introuvable")
+	r.SetTemplate("BAD", "bad %s")
+	err := r.Validate()
+	if err == nil || !strings.Contains(err.Error(), "locale fr has placeholders none, want {}") || !strings.Contains(err.Error(), "code BAD") {
+		t.Errorf("err = %v", err)
+	}
+	if err := RegistryValidate(); err != nil {
+		t.Errorf("default registry: %v", err)
+	}
+
+	r = NewRegistry()
+	r.SetTemplate("USER_NOT_FOUND", "user {id} not found in {region}")
+	r.SetMessage("USER_NOT_FOUND", "de", "In {region} gibt es keinen Benutzer {id}")
+	if err := r.Validate(); err != nil {
+		t.Fatal(err)
+	}
+	r.SetMessage("USER_NOT_FOUND", "fr", "utilisateur {user_id} introuvable dans {region}")
+	if err := r.Validate(); err == nil || !strings.Contains(err.Error(), "locale fr has placeholders {region} {user_id}, want {id} {region}") {
+		t.Errorf("err = %v", err)
+	}
+
+	r = NewRegistry()
+	r.MustRegister("DECLARED", "declared {id")
+	if err := r.Validate(); err == nil || !strings.Contains(err.Error(), "code DECLARED: template") {
+		t.Errorf("declared template not linted: %v", err)
+	}
+}
@@ -0,0 +1,86 @@
+// Package logrusx expands baseError values into logrus fields.
+package logrusx
+
+import (
+	"errors"
+
+	baseError "github.com/go-tron/base-error"
+	"github.com/sirupsen/logrus"
+)
+
+// Field keys set by Fields.
+const (
+	KeyCode   = "error_code"
+	KeyMsg    = "error_msg"
+	KeySystem = "error_system"
+	KeyChain  = "error_chain"
+)
+
+// Fields returns the code, message, System flag and chain of the first *Error in err's
+// chain, or nil if there is none.
+func Fields(err error) logrus.Fields {
+	var e *baseError.Error
+	if !errors.As(err, &e) || e == nil {
+		return nil
+	}
+	fields := logrus.Fields{KeyCode: e.Code, KeyMsg: e.Msg, KeySystem: e.System}
+	if e.Chain != "" {
+		fields[KeyChain] = e.Chain
+	}
+	return fields
+}
+
+// Hook adds Fields of the error passed with WithError to every entry. Install it with
+// logger.AddHook(logrusx.Hook{}).
+type Hook struct{}
+
+func (Hook) Levels() []logrus.Level {
+	return logrus.AllLevels
+}
+
+func (Hook) Fire(entry *logrus.Entry) error {
+	err, ok := entry.Data[logrus.ErrorKey].(error)
+	if !ok {
+		return nil
+	}
+	for k, v := range Fields(err) {
+		if _, taken := entry.Data[k]; !taken {
+			entry.Data[k] = v
+		}
+	}
+	return nil
+}
+
+// Level returns the logrus level of baseError.LogLevel(err).
+func Level(err error) logrus.Level {
+	switch baseError.LogLevel(err) {
+	case baseError.LevelDebug:
+		return logrus.DebugLevel
+	case baseError.LevelInfo:
+		return logrus.InfoLevel
+	case baseError.LevelWarn:
+		return logrus.WarnLevel
+	}
+	return logrus.ErrorLevel
+}
+
+// Log logs err with entry.WithError at Level(err). Nothing is logged when
+// baseError.ShouldLog rejects err for the level of entry's logger.
+func Log(entry *logrus.Entry, msg string, err error) {
+	if !baseError.ShouldLog(err, minLevel(entry.Logger.GetLevel())) {
+		return
+	}
+	entry.WithError(err).Log(Level(err), msg)
+}
+
+func minLevel(l logrus.Level) baseError.Level {
+	switch {
+	case l >= logrus.DebugLevel:
+		return baseError.LevelDebug
+	case l == logrus.InfoLevel:
+		return baseError.LevelInfo
+	case l == logrus.WarnLevel:
+		return baseError.LevelWarn
+	}
+	return baseError.LevelError
+}
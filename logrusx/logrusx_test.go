@@ -0,0 +1,74 @@
+package logrusx
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"testing"
+
+	baseError "github.com/go-tron/base-error"
+	"github.com/sirupsen/logrus"
+)
+
+func TestHook(t *testing.T) {
+	var buf bytes.Buffer
+	logger := logrus.New()
+	logger.SetOutput(&buf)
+	logger.SetFormatter(&logrus.JSONFormatter{})
+	logger.AddHook(Hook{})
+
+	err := fmt.Errorf("charge: %w", baseError.System("LOGRUSX_LEDGER", "ledger down").WithChain("charge", "ledger.Post"))
+	logger.WithError(err).Error("failed")
+
+	var line map[string]interface{}
+	if err := json.Unmarshal(buf.Bytes(), &line); err != nil {
+		t.Fatal(err)
+	}
+	if line[KeyCode] != "LOGRUSX_LEDGER" || line[KeyMsg] != "ledger down" || line[KeySystem] != true || line[KeyChain] != "charge<-ledger.Post" {
+		t.Errorf("line = %v", line)
+	}
+	if line["error"] != err.Error() {
+		t.Errorf("error = %v", line["error"])
+	}
+}
+
+func TestFields(t *testing.T) {
+	if Fields(errors.New("raw")) != nil {
+		t.Error("foreign error expanded")
+	}
+	if f := Fields(baseError.New("LOGRUSX_BAD", "bad")); len(f) != 3 || f[KeySystem] != false {
+		t.Errorf("fields = %v", f)
+	}
+}
+
+func TestLog(t *testing.T) {
+	var buf bytes.Buffer
+	logger := logrus.New()
+	logger.SetOutput(&buf)
+	logger.SetFormatter(&logrus.JSONFormatter{})
+	logger.SetLevel(logrus.InfoLevel)
+	logger.AddHook(Hook{})
+	baseError.DefaultRegistry.SetLogLevel("LOGRUSX_QUIET", baseError.LevelDebug)
+	baseError.DefaultRegistry.SetLogLevel("LOGRUSX_NOTE", baseError.LevelInfo)
+
+	Log(logrus.NewEntry(logger), "quiet", baseError.System("LOGRUSX_QUIET", ""))
+	baseError.Suppress("LOGRUSX_MUTED", 0)
+	defer baseError.Unsuppress("LOGRUSX_MUTED")
+	Log(logrus.NewEntry(logger), "muted", baseError.System("LOGRUSX_MUTED", ""))
+	if buf.Len() != 0 {
+		t.Fatalf("logged %s", buf.String())
+	}
+
+	Log(logger.WithField("req", "r1"), "note", baseError.System("LOGRUSX_NOTE", ""))
+	var line map[string]interface{}
+	if err := json.Unmarshal(buf.Bytes(), &line); err != nil {
+		t.Fatal(err)
+	}
+	if line["level"] != "info" || line["req"] != "r1" || line[KeyCode] != "LOGRUSX_NOTE" {
+		t.Errorf("line = %v", line)
+	}
+	if Level(baseError.New("LOGRUSX_PLAIN", "")) != logrus.WarnLevel {
+		t.Error("business errors default to warn")
+	}
+}
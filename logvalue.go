@@ -0,0 +1,43 @@
+//go:build go1.21
+
+package baseError
+
+import (
+	"fmt"
+	"log/slog"
+)
+
+var _ slog.LogValuer = (*Error)(nil)
+
+// SetLogStack sets whether LogValue includes the stack frames; see Config.LogStack.
+func SetLogStack(on bool) {
+	updateConfig(func(c *Config) {
+		c.LogStack = on
+	})
+}
+
+// LogValue makes log/slog record b as a group of its code, msg, system flag, chain if
+// set and, with Config.LogStack, its stack as "function file:line" strings.
+func (b *Error) LogValue() slog.Value {
+	if b == nil {
+		return slog.Value{}
+	}
+	attrs := []slog.Attr{
+		slog.String("code", b.Code),
+		slog.String("msg", b.Msg),
+		slog.Bool("system", b.System),
+	}
+	if b.Chain != "" {
+		attrs = append(attrs, slog.String("chain", b.Chain))
+	}
+	if b.cfg().LogStack {
+		if frames := b.stack.Frames(); len(frames) > 0 {
+			stack := make([]string, len(frames))
+			for i, f := range frames {
+				stack[i] = fmt.Sprintf("%s %s:%d", f.Function, f.File, f.Line)
+			}
+			attrs = append(attrs, slog.Any("stack", stack))
+		}
+	}
+	return slog.GroupValue(attrs...)
+}
@@ -0,0 +1,43 @@
+//go:build go1.21
+
+package baseError
+
+import (
+	"bytes"
+	"encoding/json"
+	"log/slog"
+	"testing"
+)
+
+func TestLogValue(t *testing.T) {
+	prev := CurrentConfig()
+	defer Configure(prev)
+
+	log := func(err *Error) map[string]interface{} {
+		var buf bytes.Buffer
+		slog.New(slog.NewJSONHandler(&buf, nil)).Error("failed", "err", err)
+		var line map[string]interface{}
+		if err := json.Unmarshal(buf.Bytes(), &line); err != nil {
+			t.Fatal(err)
+		}
+		v, _ := line["err"].(map[string]interface{})
+		return v
+	}
+
+	e := SystemStack("LOGVALUE_DB", "db down", 4).WithChain("save", "db.Exec")
+	got := log(e)
+	if got["code"] != "LOGVALUE_DB" || got["msg"] != "db down" || got["system"] != true || got["chain"] != "save<-db.Exec" {
+		t.Errorf("got %v", got)
+	}
+	if _, ok := got["stack"]; ok {
+		t.Error("stack logged by default")
+	}
+
+	SetLogStack(true)
+	if st, _ := log(e)["stack"].([]interface{}); len(st) == 0 {
+		t.Error("stack missing")
+	}
+	if got := log(New("LOGVALUE_BAD", "")); got["system"] != false || got["chain"] != nil {
+		t.Errorf("business = %v", got)
+	}
+}
@@ -0,0 +1,23 @@
+module github.com/go-tron/base-error/lro
+
+go 1.26.0
+
+require (
+	github.com/go-tron/base-error v0.0.0-00010101000000-000000000000
+	github.com/go-tron/base-error/grpcx v0.0.0-00010101000000-000000000000
+	google.golang.org/genproto/googleapis/rpc v0.0.0-20260921155816-b14227669459
+	google.golang.org/grpc v1.84.0
+	google.golang.org/protobuf v1.36.12
+)
+
+require (
+	github.com/pkg/errors v0.9.1 // indirect
+	golang.org/x/net v0.59.0 // indirect
+	golang.org/x/sys v0.48.0 // indirect
+	golang.org/x/text v0.42.0 // indirect
+)
+
+replace (
+	github.com/go-tron/base-error => ../
+	github.com/go-tron/base-error/grpcx => ../grpcx
+)
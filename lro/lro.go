@@ -0,0 +1,69 @@
+// Package lro renders the outcome of long-running operations in the
+// google.longrunning.Operation shape, for job APIs polled by clients:
+//
+//	{"name": "jobs/42", "done": true, "error": {"code": 5, "message": "...", "details": [...]}}
+//
+// The error is a google.rpc.Status in its canonical JSON form, carrying the *Error code
+// and fields the way grpcx.ToStatus does.
+package lro
+
+import (
+	"encoding/json"
+
+	baseError "github.com/go-tron/base-error"
+	"github.com/go-tron/base-error/grpcx"
+	spb "google.golang.org/genproto/googleapis/rpc/status"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/protojson"
+)
+
+// Operation is the status object of one long-running operation.
+type Operation struct {
+	Name     string          `json:"name,omitempty"`
+	Metadata json.RawMessage `json:"metadata,omitempty"`
+	Done     bool            `json:"done"`
+	Error    *Status         `json:"error,omitempty"`
+	Response json.RawMessage `json:"response,omitempty"`
+}
+
+// Status is a gRPC status encoded as google.rpc.Status JSON.
+type Status struct {
+	*status.Status
+}
+
+func (s *Status) MarshalJSON() ([]byte, error) {
+	return protojson.Marshal(s.Proto())
+}
+
+func (s *Status) UnmarshalJSON(data []byte) error {
+	var p spb.Status
+	if err := (protojson.UnmarshalOptions{DiscardUnknown: true}).Unmarshal(data, &p); err != nil {
+		return err
+	}
+	s.Status = status.FromProto(&p)
+	return nil
+}
+
+// Failed returns the finished operation name that failed with err. Like grpcx.ToStatus
+// it sends the message as is; sanitize System errors first on public APIs.
+func Failed(name string, err error) *Operation {
+	return &Operation{Name: name, Done: true, Error: &Status{grpcx.ToStatus(err)}}
+}
+
+// Err returns the failure of a finished operation, or nil while it is running or when it
+// succeeded.
+func (o *Operation) Err() *baseError.Error {
+	if o == nil || !o.Done || o.Error == nil || o.Error.Status == nil {
+		return nil
+	}
+	return grpcx.FromStatus(o.Error.Status)
+}
+
+// Parse decodes a polled operation status body.
+func Parse(body []byte) (*Operation, error) {
+	var o Operation
+	if err := json.Unmarshal(body, &o); err != nil {
+		return nil, err
+	}
+	return &o, nil
+}
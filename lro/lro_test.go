@@ -0,0 +1,46 @@
+package lro
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	baseError "github.com/go-tron/base-error"
+)
+
+func TestRoundTrip(t *testing.T) {
+	src := baseError.New("DAG_CYCLE", "dag has a cycle").WithField("node", "build")
+	body, err := json.Marshal(Failed("jobs/42", src))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, want := range []string{`"name":"jobs/42"`, `"done":true`, `"error":{`, `"reason":"DAG_CYCLE"`, `google.rpc.ErrorInfo`} {
+		if !strings.Contains(strings.ReplaceAll(string(body), " ", ""), want) {
+			t.Errorf("body %s lacks %s", body, want)
+		}
+	}
+
+	op, err := Parse(body)
+	if err != nil {
+		t.Fatal(err)
+	}
+	e := op.Err()
+	if e == nil || e.Code != "DAG_CYCLE" || e.Msg != "dag has a cycle" || e.System || e.Fields()["node"] != "build" {
+		t.Errorf("err = %+v", e)
+	}
+}
+
+func TestPending(t *testing.T) {
+	op, err := Parse([]byte(`{"name":"jobs/1","done":false,"metadata":{"progress":0.5}}`))
+	if err != nil || op.Err() != nil || string(op.Metadata) != `{"progress":0.5}` {
+		t.Errorf("op = %+v err = %v", op, err)
+	}
+	op, err = Parse([]byte(`{"done":true,"response":{"rows":3}}`))
+	if err != nil || op.Err() != nil {
+		t.Errorf("op = %+v err = %v", op, err)
+	}
+	op, err = Parse([]byte(`{"done":true,"error":{"code":14,"message":"backend down"}}`))
+	if e := op.Err(); err != nil || e == nil || e.Code != "UNAVAILABLE" || !e.System {
+		t.Errorf("err = %v %v", e, err)
+	}
+}
@@ -0,0 +1,44 @@
+package baseError
+
+// ErrorMaker is the construction surface of the package, for code that wants to take its
+// error constructors as a dependency. Default and every *Scope implement it; tests can
+// substitute a fake such as errtest.Recorder.
+type ErrorMaker interface {
+	New(code string, msg string) *Error
+	NewStack(code string, msg string, depth int) *Error
+	System(code string, msg string) *Error
+	SystemStack(code string, msg string, depth int) *Error
+	Wrap(code string, err error) *Error
+	WrapStack(code string, err error, depth int) *Error
+}
+
+// Default is the ErrorMaker backed by the package-level constructors.
+var Default ErrorMaker = defaultMaker{}
+
+type defaultMaker struct{}
+
+func (defaultMaker) New(code string, msg string) *Error {
+	return New(code, msg)
+}
+
+func (defaultMaker) NewStack(code string, msg string, depth int) *Error {
+	return NewStack(code, msg, depth)
+}
+
+func (defaultMaker) System(code string, msg string) *Error {
+	return System(code, msg)
+}
+
+func (defaultMaker) SystemStack(code string, msg string, depth int) *Error {
+	return SystemStack(code, msg, depth)
+}
+
+func (defaultMaker) Wrap(code string, err error) *Error {
+	return Wrap(code, err)
+}
+
+func (defaultMaker) WrapStack(code string, err error, depth int) *Error {
+	return WrapStack(code, err, depth)
+}
+
+var _ ErrorMaker = (*Scope)(nil)
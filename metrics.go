@@ -0,0 +1,48 @@
+package baseError
+
+import (
+	"context"
+	"time"
+)
+
+const CodeOK = "OK"
+
+// MetricsRecorder receives the outcome of operations tracked with Track. code is CodeOK
+// for operations that succeeded.
+type MetricsRecorder interface {
+	RecordOperation(ctx context.Context, op string, code string, latency time.Duration)
+}
+
+type MetricsRecorderFunc func(ctx context.Context, op string, code string, latency time.Duration)
+
+func (f MetricsRecorderFunc) RecordOperation(ctx context.Context, op string, code string, latency time.Duration) {
+	f(ctx, op, code, latency)
+}
+
+func SetMetricsRecorder(r MetricsRecorder) {
+	updateConfig(func(c *Config) {
+		c.MetricsRecorder = r
+	})
+}
+
+// Track starts timing op. The returned function is meant to be deferred with a pointer to
+// the caller's named error result:
+//
+//	func (s *Service) Load(ctx context.Context) (err error) {
+//		defer baseError.Track(ctx, "service.Load")(&err)
+//		...
+//	}
+func Track(ctx context.Context, op string) (done func(*error)) {
+	start := Now()
+	return func(errp *error) {
+		r := cfg().MetricsRecorder
+		if r == nil {
+			return
+		}
+		code := CodeOK
+		if errp != nil && *errp != nil {
+			code = CodeOf(*errp)
+		}
+		r.RecordOperation(ctx, op, code, Since(start))
+	}
+}
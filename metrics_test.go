@@ -0,0 +1,28 @@
+package baseError
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestTrack(t *testing.T) {
+	counts := map[string]int{}
+	SetMetricsRecorder(MetricsRecorderFunc(func(ctx context.Context, op string, code string, latency time.Duration) {
+		counts[op+":"+code]++
+	}))
+	defer SetMetricsRecorder(nil)
+
+	call := func(err error) (ret error) {
+		defer Track(context.Background(), "op")(&ret)
+		return err
+	}
+	call(nil)
+	call(New("NOT_FOUND", ""))
+	call(errors.New("raw"))
+
+	if counts["op:OK"] != 1 || counts["op:NOT_FOUND"] != 1 || counts["op:UNKNOWN"] != 1 {
+		t.Errorf("counts = %v", counts)
+	}
+}
@@ -0,0 +1,46 @@
+// Package mqttx builds MQTT payloads describing baseError values, for edge agents that
+// announce failures through the broker. It doesn't depend on an MQTT client.
+package mqttx
+
+import (
+	"encoding/json"
+	"time"
+
+	baseError "github.com/go-tron/base-error"
+)
+
+// Will is the last-will payload of an agent.
+type Will struct {
+	Agent    string     `json:"agent"`
+	Code     string     `json:"code,omitempty"`
+	Msg      string     `json:"msg,omitempty"`
+	Severity string     `json:"severity,omitempty"`
+	Ref      string     `json:"ref,omitempty"`
+	At       *time.Time `json:"at,omitempty"`
+}
+
+// LastWill returns the JSON last-will payload of agent: the sanitized code and message of
+// the most recent Critical error kept by ring, or only the agent name if there is none or
+// ring is nil. The broker stores the will sent in CONNECT, so an agent refreshing it after
+// a Critical error has to reconnect with the new payload; the broker then announces why
+// the agent died when it detects the disconnection.
+func LastWill(ring *baseError.Ring, agent string) []byte {
+	will := Will{Agent: agent}
+	if entry, ok := last(ring); ok {
+		s := baseError.Sanitize(entry.Err)
+		will.Code, will.Msg = s.Code, s.Msg
+		will.Severity = baseError.SeverityOf(entry.Err).String()
+		will.Ref, _ = s.Fields()[baseError.FieldReference].(string)
+		at := entry.At.UTC()
+		will.At = &at
+	}
+	buf, _ := json.Marshal(will)
+	return buf
+}
+
+func last(ring *baseError.Ring) (baseError.RingEntry, bool) {
+	if ring == nil {
+		return baseError.RingEntry{}, false
+	}
+	return ring.Last(baseError.SeverityCritical)
+}
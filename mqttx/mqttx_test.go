@@ -0,0 +1,30 @@
+package mqttx
+
+import (
+	"encoding/json"
+	"testing"
+
+	baseError "github.com/go-tron/base-error"
+)
+
+func TestLastWill(t *testing.T) {
+	if got := string(LastWill(nil, "edge-1")); got != `{"agent":"edge-1"}` {
+		t.Errorf("nil ring will = %s", got)
+	}
+	ring := baseError.NewRing(8)
+	if got := string(LastWill(ring, "edge-1")); got != `{"agent":"edge-1"}` {
+		t.Errorf("empty will = %s", got)
+	}
+
+	ring.Add(baseError.New("MQTTX_SENSOR_STALE", "stale").WithSeverity(baseError.SeverityWarning))
+	ring.Add(baseError.System("MQTTX_DISK_FULL", "/var full").WithSeverity(baseError.SeverityCritical))
+	ring.Add(baseError.New("MQTTX_SENSOR_STALE", "stale"))
+
+	var will Will
+	if err := json.Unmarshal(LastWill(ring, "edge-1"), &will); err != nil {
+		t.Fatal(err)
+	}
+	if will.Code != baseError.CurrentConfig().SanitizedCode || will.Severity != "critical" || will.Ref == "" || will.At == nil || will.At.IsZero() {
+		t.Errorf("will = %+v", will)
+	}
+}
@@ -0,0 +1,120 @@
+package baseError
+
+import (
+	"fmt"
+	"strings"
+)
+
+const FieldOwner = "owner"
+
+// Namespace builds errors whose codes are qualified by a team or module prefix, so
+// ORDER's "NOT_FOUND" becomes "ORDER.NOT_FOUND" and can't collide with another team's.
+// Its codes live in a sub-registry mounted on DefaultRegistry; unlike a Scope it shares
+// the global configuration and hooks.
+type Namespace struct {
+	owner    string
+	registry *Registry
+	ctors    constructors
+}
+
+// NewNamespace mounts a sub-registry for prefix on DefaultRegistry. Prefixes are upper-case
+// letters, digits and underscores. It panics if prefix is malformed or already taken,
+// since namespaces are declared at package initialization.
+func NewNamespace(prefix string) *Namespace {
+	if !validPrefix(prefix) {
+		panic(fmt.Sprintf("baseError: invalid namespace prefix %q", prefix))
+	}
+	n := &Namespace{owner: LocalOrigin().Service, registry: NewSubRegistry(prefix)}
+	if err := DefaultRegistry.Mount(n.registry); err != nil {
+		panic(err)
+	}
+	n.ctors = constructors{
+		registry: n.registry,
+		depths:   DefaultRegistry,
+		config:   cfg,
+		code:     n.Code,
+		finish: func(e *Error) {
+			if n.owner != "" {
+				e.WithField(FieldOwner, n.owner)
+			}
+		},
+	}
+	return n
+}
+
+func validPrefix(prefix string) bool {
+	if prefix == "" {
+		return false
+	}
+	for _, r := range prefix {
+		if r != '_' && !('A' <= r && r <= 'Z') && !('0' <= r && r <= '9') {
+			return false
+		}
+	}
+	return !strings.HasPrefix(prefix, "_")
+}
+
+// WithOwner sets the service stamped as the owner field of the namespace's errors, the
+// local SERVICE_NAME by default.
+func (n *Namespace) WithOwner(service string) *Namespace {
+	n.owner = service
+	return n
+}
+
+func (n *Namespace) Prefix() string {
+	return n.registry.Prefix()
+}
+
+func (n *Namespace) Owner() string {
+	return n.owner
+}
+
+func (n *Namespace) Registry() *Registry {
+	return n.registry
+}
+
+// Code returns code qualified with the prefix, for comparisons such as
+// CodeOf(err) == orders.Code("NOT_FOUND").
+func (n *Namespace) Code(code string) string {
+	return n.registry.Qualify(code)
+}
+
+func (n *Namespace) New(code string, msg string) *Error {
+	return n.ctors.new(code, msg, false)
+}
+
+func (n *Namespace) NewStack(code string, msg string, depth int) *Error {
+	return n.ctors.newStack(code, msg, false, depth)
+}
+
+func (n *Namespace) System(code string, msg string) *Error {
+	return n.ctors.new(code, msg, true)
+}
+
+func (n *Namespace) SystemStack(code string, msg string, depth int) *Error {
+	return n.ctors.newStack(code, msg, true, depth)
+}
+
+func (n *Namespace) Factory(arg ...string) func(...interface{}) *Error {
+	return n.ctors.factory(arg, false, false, 0)
+}
+
+func (n *Namespace) FactoryStack(depth int, arg ...string) func(...interface{}) *Error {
+	return n.ctors.factory(arg, false, true, depth)
+}
+
+func (n *Namespace) SystemFactory(arg ...string) func(...interface{}) *Error {
+	return n.ctors.factory(arg, true, false, 0)
+}
+
+func (n *Namespace) SystemFactoryStack(depth int, arg ...string) func(...interface{}) *Error {
+	return n.ctors.factory(arg, true, true, depth)
+}
+
+func (n *Namespace) Wrap(code string, err error) *Error {
+	return n.ctors.wrap(code, err)
+}
+
+func (n *Namespace) WrapStack(code string, err error, depth int) *Error {
+	return n.ctors.wrapStack(code, err, depth)
+}
@@ -0,0 +1,51 @@
+package baseError
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+)
+
+var orders = NewNamespace("NSORDER").WithOwner("order-service")
+
+func TestNamespace(t *testing.T) {
+	orders.Registry().SetHTTPStatus("NOT_FOUND", http.StatusNotFound)
+	notFound := orders.Factory("NOT_FOUND", "order {} not found")
+
+	e := notFound(42)
+	if e.Code != "NSORDER.NOT_FOUND" || e.Msg != "order 42 not found" || e.Fields()[FieldOwner] != "order-service" {
+		t.Errorf("e = %+v", e)
+	}
+	if CodeOf(e) != orders.Code("NOT_FOUND") || HTTPStatus(e) != http.StatusNotFound {
+		t.Errorf("code = %s status = %d", CodeOf(e), HTTPStatus(e))
+	}
+	if w := orders.Wrap("DB", errors.New("down")); w.Code != "NSORDER.DB" || !w.System {
+		t.Errorf("w = %+v", w)
+	}
+	if e := orders.New("NSORDER.GONE", ""); e.Code != "NSORDER.GONE" {
+		t.Errorf("code = %s", e.Code)
+	}
+
+	found := false
+	for _, reg := range DefaultRegistry.List() {
+		if reg.Code == "NSORDER.NOT_FOUND" && reg.Template == "order {} not found" {
+			found = true
+		}
+	}
+	if !found {
+		t.Error("factory code not listed in DefaultRegistry")
+	}
+}
+
+func TestNewNamespacePanics(t *testing.T) {
+	for _, prefix := range []string{"NSORDER", "order", "", "_X"} {
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Errorf("NewNamespace(%q) did not panic", prefix)
+				}
+			}()
+			NewNamespace(prefix)
+		}()
+	}
+}
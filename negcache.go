@@ -0,0 +1,140 @@
+package baseError
+
+import (
+	"sync"
+	"time"
+)
+
+const (
+	FieldCachedAt   = "cached_at"
+	FieldObservedAt = "observed_at"
+)
+
+// NegativeCache memoizes failed lookups for ttl so that keys known to be missing don't
+// hit the downstream again. Only business errors, restricted to codes when any are given,
+// are cached; System and foreign errors always pass through. Each hit returns a copy of
+// the cached error without its volatile fields, stamped with observed_at and with
+// cached_at set to when the lookup actually failed.
+type NegativeCache struct {
+	ttl     time.Duration
+	codes   map[string]bool
+	mu      sync.Mutex
+	entries map[string]negativeEntry
+	sweepAt int
+}
+
+// negativeSweepMin is the size from which Put starts sweeping expired entries.
+const negativeSweepMin = 64
+
+type negativeEntry struct {
+	err     *Error
+	at      time.Time
+	expires time.Time
+}
+
+func NewNegativeCache(ttl time.Duration, codes ...string) *NegativeCache {
+	c := &NegativeCache{ttl: ttl, entries: make(map[string]negativeEntry), sweepAt: negativeSweepMin}
+	if len(codes) > 0 {
+		c.codes = make(map[string]bool, len(codes))
+		for _, code := range codes {
+			c.codes[code] = true
+		}
+	}
+	return c
+}
+
+// Do returns the cached error for key if there is a live one, otherwise it calls fn and
+// caches what it returns when that is a cacheable error.
+func (c *NegativeCache) Do(key string, fn func() error) error {
+	if err := c.Get(key); err != nil {
+		return err
+	}
+	err := fn()
+	c.Put(key, err)
+	return err
+}
+
+func (c *NegativeCache) Get(key string) *Error {
+	at := Now()
+	c.mu.Lock()
+	entry, ok := c.entries[key]
+	if ok && !at.Before(entry.expires) {
+		delete(c.entries, key)
+		ok = false
+	}
+	c.mu.Unlock()
+	if !ok {
+		return nil
+	}
+	return entry.err.clone().
+		WithField(FieldCachedAt, entry.at).
+		WithField(FieldObservedAt, at)
+}
+
+// Put caches the first *Error in err's chain, not err itself: context added by wrapping
+// it, such as a fmt.Errorf prefix, describes the one failed call and is dropped. Expired
+// entries of other keys are swept whenever the cache has doubled since the last sweep,
+// so it only grows with the keys that failed within ttl.
+func (c *NegativeCache) Put(key string, err error) {
+	e := errorOf(err)
+	if e == nil || e.System || (c.codes != nil && !c.codes[e.Code]) {
+		return
+	}
+	at := Now()
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if len(c.entries) >= c.sweepAt {
+		c.sweep(at)
+		c.sweepAt = 2 * len(c.entries)
+		if c.sweepAt < negativeSweepMin {
+			c.sweepAt = negativeSweepMin
+		}
+	}
+	c.entries[key] = negativeEntry{err: e.clone(), at: at, expires: at.Add(c.ttl)}
+}
+
+// sweep deletes the entries expired at at. The caller holds c.mu.
+func (c *NegativeCache) sweep(at time.Time) {
+	for key, entry := range c.entries {
+		if !at.Before(entry.expires) {
+			delete(c.entries, key)
+		}
+	}
+}
+
+func (c *NegativeCache) Forget(key string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	delete(c.entries, key)
+}
+
+func (c *NegativeCache) Len() int {
+	at := Now()
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.sweep(at)
+	return len(c.entries)
+}
+
+// clone copies b without its volatile fields, so copies don't share mutable state.
+func (b *Error) clone() *Error {
+	out := *b
+	out.fields = nil
+	for k, v := range b.fields {
+		if !isVolatile(k) {
+			out.WithField(k, v)
+		}
+	}
+	out.safeDetails = append([]string(nil), b.safeDetails...)
+	out.debugDetails = append([]string(nil), b.debugDetails...)
+	return &out
+}
+
+func isVolatile(key string) bool {
+	for _, k := range volatileFields {
+		if k == key {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,80 @@
+package baseError
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+)
+
+func TestNegativeCache(t *testing.T) {
+	prev := CurrentConfig()
+	defer Configure(prev)
+	at := time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)
+	c := CurrentConfig()
+	c.Clock = ClockFunc(func() time.Time { return at })
+	Configure(c)
+
+	cache := NewNegativeCache(time.Minute, "USER_NOT_FOUND")
+	calls := 0
+	lookup := func() error {
+		calls++
+		return New("USER_NOT_FOUND", "user 42 not found").WithField("user", 42).WithField(FieldReference, "r1")
+	}
+	first := cache.Do("42", lookup)
+	at = at.Add(10 * time.Second)
+	second := cache.Do("42", lookup)
+	if calls != 1 {
+		t.Fatalf("calls = %d", calls)
+	}
+	var e *Error
+	if !errors.As(second, &e) || e == first || !Equal(first, second) {
+		t.Fatalf("second = %#v", second)
+	}
+	f := e.Fields()
+	if _, ok := f[FieldReference]; ok || f[FieldObservedAt] != at || !f[FieldCachedAt].(time.Time).Equal(at.Add(-10*time.Second)) {
+		t.Errorf("fields = %v", f)
+	}
+	e.WithField("user", 7)
+	if cache.Get("42").Fields()["user"] != 42 {
+		t.Error("cached error was mutated through a hit")
+	}
+
+	at = at.Add(time.Minute)
+	cache.Do("42", lookup)
+	if calls != 2 {
+		t.Errorf("expired entry served, calls = %d", calls)
+	}
+
+	cache.Put("sys", System("USER_NOT_FOUND", "db down"))
+	cache.Put("other", New("USER_BANNED", ""))
+	cache.Put("raw", errors.New("raw"))
+	if cache.Len() != 1 {
+		t.Errorf("len = %d", cache.Len())
+	}
+	cache.Forget("42")
+	if cache.Get("42") != nil {
+		t.Error("forgotten entry served")
+	}
+}
+
+func TestNegativeCacheSweeps(t *testing.T) {
+	prev := CurrentConfig()
+	defer Configure(prev)
+	at := time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)
+	c := CurrentConfig()
+	c.Clock = ClockFunc(func() time.Time { return at })
+	Configure(c)
+
+	cache := NewNegativeCache(time.Minute)
+	for i := 0; i < 1000; i++ {
+		cache.Put(fmt.Sprint(i), New("USER_NOT_FOUND", ""))
+		at = at.Add(time.Second)
+	}
+	cache.mu.Lock()
+	n := len(cache.entries)
+	cache.mu.Unlock()
+	if n > 2*negativeSweepMin {
+		t.Errorf("%d entries kept for a one minute ttl", n)
+	}
+}
@@ -0,0 +1,103 @@
+package baseError
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"testing"
+)
+
+// nilSafe lists the helpers that must accept a nil error and a typed nil *Error, so that
+// they're safe to call from recover paths and finalizers.
+var nilSafe = map[string]func(err error){
+	"IsSystemError":        func(err error) { IsSystemError(err) },
+	"CodeOf":               func(err error) { CodeOf(err) },
+	"CodeOfOr":             func(err error) { CodeOfOr(err, "X") },
+	"IsRetryable":          func(err error) { IsRetryable(err) },
+	"SeverityOf":           func(err error) { SeverityOf(err) },
+	"LogLevel":             func(err error) { LogLevel(err) },
+	"ShouldLog":            func(err error) { ShouldLog(err, LevelDebug) },
+	"HTTPStatus":           func(err error) { HTTPStatus(err) },
+	"Fingerprint":          func(err error) { Fingerprint(err) },
+	"Size":                 func(err error) { Size(err) },
+	"StackFrames":          func(err error) { StackFrames(err) },
+	"Suppressed":           func(err error) { Suppressed(err) },
+	"Report":               func(err error) { Report(err) },
+	"IsFirstOccurrence":    func(err error) { IsFirstOccurrence(err) },
+	"Sanitize":             func(err error) { Sanitize(err) },
+	"ToProblem":            func(err error) { ToProblem(err) },
+	"DefaultPriority":      func(err error) { DefaultPriority(err) },
+	"NetCode":              func(err error) { NetCode(err) },
+	"FSCode":               func(err error) { FSCode(err) },
+	"Prioritize":           func(err error) { Prioritize(err) },
+	"Equal":                func(err error) { Equal(err, err) },
+	"Wrap":                 func(err error) { Wrap("X", err) },
+	"Join":                 func(err error) { Join("X", err) },
+	"CaptureAllGoroutines": func(err error) { CaptureAllGoroutines(err) },
+	"Sprintf":              func(err error) { _ = fmt.Sprintf("%v %+v %s %q %x", err, err, err, err, err) },
+	"Error":                func(err error) { _ = err.Error() },
+	"MarshalJSON":          func(err error) { json.Marshal(err) },
+	"MarshalLimited":       func(err error) { e, _ := err.(*Error); MarshalLimited(e, 8) },
+	"EncodeCompact":        func(err error) { e, _ := err.(*Error); EncodeCompact(e, 8) },
+}
+
+func TestNilSafety(t *testing.T) {
+	var typed *Error
+	for name, fn := range nilSafe {
+		for _, err := range []error{nil, typed} {
+			if err == nil && (name == "Error") {
+				continue
+			}
+			func() {
+				defer func() {
+					if r := recover(); r != nil {
+						t.Errorf("%s(%#v) panicked: %v", name, err, r)
+					}
+				}()
+				fn(err)
+			}()
+		}
+	}
+}
+
+func TestNilMethods(t *testing.T) {
+	var e *Error
+	defer func() {
+		if r := recover(); r != nil {
+			t.Fatalf("panicked: %v", r)
+		}
+	}()
+	if e.Error() != "<nil>" || e.Cause() != nil || e.Unwrap() != nil || e.Causes() != nil || e.Fields() != nil {
+		t.Error("nil *Error isn't empty")
+	}
+	if e.Stack() != nil || e.SafeDetails() != nil || e.DebugDetails() != nil || e.Sanitized() || e.Scope() != nil || e.Attempt() != 0 || e.RetryHistory() != nil || !e.Origin().IsZero() {
+		t.Error("nil *Error accessors aren't zero")
+	}
+	if e.Is(New("A", "")) {
+		t.Error("nil *Error matched")
+	}
+	var buf bytes.Buffer
+	e.WriteTo(&buf)
+	if buf, _ := e.MarshalJSON(); string(buf) != "null" {
+		t.Errorf("json = %s", buf)
+	}
+	if buf, err := MarshalLimited(e, 8); string(buf) != "null" || err != nil {
+		t.Errorf("limited = %s, %v", buf, err)
+	}
+	if s, err := EncodeCompact(e, 8); s != "" || err != nil {
+		t.Errorf("compact = %q, %v", s, err)
+	}
+	if d, err := DecodeCompact(""); d != nil || err != nil {
+		t.Errorf("decoded = %v, %v", d, err)
+	}
+}
+
+func TestIsSystemErrorAllocs(t *testing.T) {
+	err := error(System("A", ""))
+	if n := testing.AllocsPerRun(100, func() { IsSystemError(err) }); n != 0 {
+		t.Errorf("allocs = %v", n)
+	}
+	if IsSystemError(New("A", "")) || !IsSystemError(err) || IsSystemError(fmt.Errorf("x")) {
+		t.Error("IsSystemError misclassified")
+	}
+}
@@ -0,0 +1,121 @@
+package baseError
+
+import (
+	"container/list"
+	"sync"
+)
+
+const FieldFirstOccurrence = "first_occurrence"
+
+const defaultOccurrenceCapacity = 4096
+
+// occurrences holds the most recently seen fingerprints, least recent at the back.
+var occurrences struct {
+	sync.Mutex
+	capacity int
+	order    *list.List
+	seen     map[string]*list.Element
+}
+
+// MarkFirstOccurrence records err's fingerprint and reports whether it had not been seen
+// before in this process. Only the 4096 most recently seen fingerprints are remembered
+// (see SetOccurrenceCapacity), so a fingerprint evicted since is new again. err itself
+// is left unchanged; Report hands hooks a copy marked with the first_occurrence field
+// instead.
+func MarkFirstOccurrence(err *Error) bool {
+	if err == nil {
+		return false
+	}
+	fp := Fingerprint(err)
+	occurrences.Lock()
+	defer occurrences.Unlock()
+	if occurrences.seen == nil {
+		resetOccurrencesLocked()
+	}
+	if el, ok := occurrences.seen[fp]; ok {
+		occurrences.order.MoveToFront(el)
+		return false
+	}
+	occurrences.seen[fp] = occurrences.order.PushFront(fp)
+	trimOccurrencesLocked()
+	return true
+}
+
+// SetOccurrenceCapacity sets how many fingerprints MarkFirstOccurrence remembers,
+// forgetting the least recently seen ones beyond it. n <= 0 restores the default of 4096.
+func SetOccurrenceCapacity(n int) {
+	if n <= 0 {
+		n = defaultOccurrenceCapacity
+	}
+	occurrences.Lock()
+	defer occurrences.Unlock()
+	if occurrences.seen == nil {
+		resetOccurrencesLocked()
+	}
+	occurrences.capacity = n
+	trimOccurrencesLocked()
+}
+
+// ResetOccurrences forgets every fingerprint, so each error is a first occurrence again.
+func ResetOccurrences() {
+	occurrences.Lock()
+	defer occurrences.Unlock()
+	resetOccurrencesLocked()
+}
+
+func resetOccurrencesLocked() {
+	if occurrences.capacity <= 0 {
+		occurrences.capacity = defaultOccurrenceCapacity
+	}
+	occurrences.order = list.New()
+	occurrences.seen = make(map[string]*list.Element)
+}
+
+func trimOccurrencesLocked() {
+	for occurrences.order.Len() > occurrences.capacity {
+		back := occurrences.order.Back()
+		occurrences.order.Remove(back)
+		delete(occurrences.seen, back.Value.(string))
+	}
+}
+
+// firstReport marks an error reported for the first time when its outermost error is not
+// an *Error that could carry the field on a copy.
+type firstReport struct {
+	error
+}
+
+func (f *firstReport) Unwrap() error {
+	return f.error
+}
+
+// markReported returns what Report hands to hooks for err: err itself, or a copy marked
+// as the first occurrence.
+func markReported(err error, e *Error) error {
+	if !MarkFirstOccurrence(e) {
+		return err
+	}
+	if x, ok := err.(*Error); ok {
+		return x.Copy().WithField(FieldFirstOccurrence, true)
+	}
+	return &firstReport{err}
+}
+
+func IsFirstOccurrence(err error) bool {
+	for x := err; x != nil; {
+		if _, ok := x.(*firstReport); ok {
+			return true
+		}
+		u, ok := x.(interface{ Unwrap() error })
+		if !ok {
+			break
+		}
+		x = u.Unwrap()
+	}
+	e := errorOf(err)
+	if e == nil {
+		return false
+	}
+	first, _ := e.fields[FieldFirstOccurrence].(bool)
+	return first
+}
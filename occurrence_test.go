@@ -0,0 +1,70 @@
+package baseError
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestFirstOccurrence(t *testing.T) {
+	prev := CurrentConfig()
+	defer Configure(prev)
+	ResetOccurrences()
+
+	var first []bool
+	AddHook(func(err error) {
+		if CodeOf(err) == "OCCURRENCE" {
+			first = append(first, IsFirstOccurrence(err))
+		}
+	})
+	for i := 0; i < 3; i++ {
+		Report(NewStack("OCCURRENCE", "", 5))
+	}
+	if len(first) != 3 || !first[0] || first[1] || first[2] {
+		t.Errorf("first = %v", first)
+	}
+}
+
+func TestFirstOccurrenceLeavesError(t *testing.T) {
+	prev := CurrentConfig()
+	defer Configure(prev)
+	ResetOccurrences()
+
+	var first []bool
+	AddHook(func(err error) {
+		if CodeOf(err) == "OCCURRENCE_SENTINEL" {
+			first = append(first, IsFirstOccurrence(err))
+		}
+	})
+	sentinel := New("OCCURRENCE_SENTINEL", "")
+	Report(sentinel)
+	Report(sentinel)
+	if IsFirstOccurrence(sentinel) || len(sentinel.Fields()) != 0 {
+		t.Errorf("sentinel mutated: %+v", sentinel)
+	}
+	ResetOccurrences()
+	Report(fmt.Errorf("wrapped: %w", sentinel))
+	if fmt.Sprint(first) != "[true false true]" {
+		t.Errorf("first = %v", first)
+	}
+}
+
+func TestOccurrenceCapacity(t *testing.T) {
+	ResetOccurrences()
+	SetOccurrenceCapacity(2)
+	defer SetOccurrenceCapacity(0)
+
+	a, b, c := New("OCCURRENCE_A", ""), New("OCCURRENCE_B", ""), New("OCCURRENCE_C", "")
+	if !MarkFirstOccurrence(a) || !MarkFirstOccurrence(b) || MarkFirstOccurrence(a) {
+		t.Fatal("unexpected first occurrences")
+	}
+	MarkFirstOccurrence(c)
+	if occurrences.order.Len() != 2 {
+		t.Errorf("remembered %d fingerprints", occurrences.order.Len())
+	}
+	if MarkFirstOccurrence(a) {
+		t.Error("recently seen fingerprint evicted")
+	}
+	if !MarkFirstOccurrence(b) {
+		t.Error("least recently seen fingerprint kept")
+	}
+}
@@ -0,0 +1,62 @@
+package baseError
+
+import (
+	"context"
+	"time"
+)
+
+const FieldLatency = "latency"
+
+// WrapFunc decorates fn so that any failure comes back as *Error with op appended to the
+// chain, the call latency recorded and, for deadline errors, the timeout that fired.
+// Foreign errors are wrapped with code and a stack; *Error values keep their own code
+// and stack, and are annotated on a copy so shared sentinels stay unchanged.
+func WrapFunc[T any](op string, code string, fn func(ctx context.Context) (T, error)) func(ctx context.Context) (T, *Error) {
+	return func(ctx context.Context) (T, *Error) {
+		start := Now()
+		v, err := fn(ctx)
+		if isNil(err) {
+			return v, nil
+		}
+		return v, wrapOp(ctx, op, code, err, Since(start))
+	}
+}
+
+// WrapOp is the middleware form of WrapFunc for functions returning only an error.
+func WrapOp(op string, code string) func(next func(ctx context.Context) error) func(ctx context.Context) error {
+	return func(next func(ctx context.Context) error) func(ctx context.Context) error {
+		return func(ctx context.Context) error {
+			start := Now()
+			err := next(ctx)
+			if isNil(err) {
+				return nil
+			}
+			if e := wrapOp(ctx, op, code, err, Since(start)); e != nil {
+				return e
+			}
+			return nil
+		}
+	}
+}
+
+func wrapOp(ctx context.Context, op string, code string, err error, latency time.Duration) *Error {
+	e, ok := err.(*Error)
+	if ok && e == nil {
+		return nil
+	}
+	if ok {
+		e = e.Copy()
+	} else {
+		e = WrapStack(code, err, AutoDepth)
+	}
+	if e.Chain == "" {
+		e.Chain = op
+	} else {
+		e.Chain += "<-" + op
+	}
+	if _, ok := e.fields[FieldLatency]; !ok {
+		e.WithField(FieldLatency, latency)
+	}
+	attributeDeadline(ctx, e)
+	return e
+}
@@ -0,0 +1,48 @@
+package baseError
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+func TestWrapFunc(t *testing.T) {
+	find := WrapFunc("repo.Find", "DB_ERROR", func(ctx context.Context) (int, error) {
+		return 0, errors.New("connection reset")
+	})
+	_, err := find(context.Background())
+	if err == nil || err.Code != "DB_ERROR" || err.Chain != "repo.Find" || err.Stack() == nil {
+		t.Fatalf("got %+v", err)
+	}
+	if _, ok := err.Fields()[FieldLatency]; !ok {
+		t.Error("missing latency field")
+	}
+
+	load := WrapOp("service.Load", "SERVICE_ERROR")(func(ctx context.Context) error {
+		_, err := find(ctx)
+		return err
+	})
+	var e *Error
+	if !errors.As(load(context.Background()), &e) || e.Code != "DB_ERROR" || e.Chain != "repo.Find<-service.Load" {
+		t.Fatalf("got %+v", e)
+	}
+
+	ok := WrapFunc("repo.Find", "DB_ERROR", func(ctx context.Context) (int, error) { return 1, nil })
+	if v, err := ok(context.Background()); v != 1 || err != nil {
+		t.Errorf("got %d %v", v, err)
+	}
+}
+
+func TestWrapOpLeavesSentinel(t *testing.T) {
+	sentinel := New("OP_SENTINEL", "")
+	op := WrapOp("cache.Get", "CACHE")(func(context.Context) error { return sentinel })
+	for i := 0; i < 2; i++ {
+		err := op(context.Background()).(*Error)
+		if err == sentinel || err.Chain != "cache.Get" {
+			t.Errorf("err = %p chain = %q", err, err.Chain)
+		}
+	}
+	if sentinel.Chain != "" || len(sentinel.Fields()) != 0 {
+		t.Errorf("sentinel mutated: %+v", sentinel)
+	}
+}
@@ -0,0 +1,58 @@
+package baseError
+
+// Origin identifies the process an error was first raised in.
+type Origin struct {
+	Service string `json:"service,omitempty"`
+	Version string `json:"version,omitempty"`
+	Zone    string `json:"zone,omitempty"`
+}
+
+func (o Origin) IsZero() bool {
+	return o == Origin{}
+}
+
+// SetOrigin replaces the origin stamped on System errors, which is read from the
+// SERVICE_NAME, SERVICE_VERSION and SERVICE_ZONE environment variables by default.
+func SetOrigin(o Origin) {
+	updateConfig(func(c *Config) {
+		c.Origin = o
+	})
+}
+
+func LocalOrigin() Origin {
+	return cfg().Origin
+}
+
+func (b *Error) WithOrigin(o Origin) *Error {
+	b.origin = o
+	return b
+}
+
+func (b *Error) Origin() Origin {
+	if b == nil {
+		return Origin{}
+	}
+	return b.origin
+}
+
+// created is called by every constructor before running the creation hooks. System
+// errors inherit the origin of the first error in their cause chain that has one, e.g.
+// one decoded from an upstream envelope, and are stamped with LocalOrigin otherwise.
+func created(e *Error) *Error {
+	if e.System && e.origin.IsZero() {
+		e.origin = e.cfg().Origin
+		for inner := errorOf(e.cause); inner != nil; inner = errorOf(inner.cause) {
+			if !inner.origin.IsZero() {
+				e.origin = inner.origin
+				break
+			}
+		}
+	}
+	if e.cfg().Strict {
+		checkStrict(e)
+	}
+	normalize(e)
+	traceConstruction(e)
+	runCreationHooks(e)
+	return e
+}
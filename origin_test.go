@@ -0,0 +1,33 @@
+package baseError
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestOrigin(t *testing.T) {
+	prev := LocalOrigin()
+	SetOrigin(Origin{Service: "gateway", Version: "1.4.0", Zone: "eu-1"})
+	defer SetOrigin(prev)
+
+	if o := System("DB", "").Origin(); o.Service != "gateway" {
+		t.Errorf("origin = %+v", o)
+	}
+	if o := New("BAD_INPUT", "").Origin(); !o.IsZero() {
+		t.Errorf("business errors should not be stamped, got %+v", o)
+	}
+
+	upstream, err := DecodeAny([]byte(`{"code":"LEDGER_LOCKED","msg":"locked","origin":{"service":"ledger","zone":"eu-2"}}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	wrapped := Wrap("UPSTREAM_FAILED", Wrap("BILLING_FAILED", upstream))
+	if o := wrapped.Origin(); o.Service != "ledger" || o.Zone != "eu-2" {
+		t.Errorf("origin = %+v", o)
+	}
+
+	buf, _ := json.Marshal(wrapped)
+	if want := `{"code":"UPSTREAM_FAILED","msg":"[BILLING_FAILED] [LEDGER_LOCKED] locked","origin":{"service":"ledger","zone":"eu-2"}}`; string(buf) != want {
+		t.Errorf("json = %s", buf)
+	}
+}
@@ -0,0 +1,52 @@
+// Package otelx records baseError values on OpenTelemetry spans.
+package otelx
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	baseError "github.com/go-tron/base-error"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// Attribute keys added to the exception event.
+const (
+	KeyCode       = attribute.Key("error.code")
+	KeySystem     = attribute.Key("error.system")
+	KeyStacktrace = attribute.Key("exception.stacktrace")
+)
+
+// RecordSpanError records err as an exception event on span with its code, System flag
+// and captured stack, and sets the span status to Error for System and foreign errors.
+// Business errors are expected outcomes and leave the status unset.
+func RecordSpanError(span trace.Span, err error) {
+	if err == nil || !span.IsRecording() {
+		return
+	}
+	var e *baseError.Error
+	if !errors.As(err, &e) || e == nil {
+		span.RecordError(err, trace.WithStackTrace(true))
+		span.SetStatus(codes.Error, err.Error())
+		return
+	}
+	attrs := []attribute.KeyValue{KeyCode.String(e.Code), KeySystem.Bool(e.System)}
+	if st := stacktrace(err); st != "" {
+		attrs = append(attrs, KeyStacktrace.String(st))
+	}
+	span.RecordError(err, trace.WithAttributes(attrs...))
+	if e.System {
+		span.SetStatus(codes.Error, e.Error())
+	}
+}
+
+// stacktrace formats the captured frames like runtime/debug.Stack does.
+func stacktrace(err error) string {
+	var b strings.Builder
+	for _, f := range baseError.StackFrames(err) {
+		fmt.Fprintf(&b, "%s\n\t%s:%d\n", f.Function, f.File, f.Line)
+	}
+	return b.String()
+}
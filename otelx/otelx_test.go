@@ -0,0 +1,48 @@
+package otelx
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	baseError "github.com/go-tron/base-error"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+func TestRecordSpanError(t *testing.T) {
+	rec := tracetest.NewSpanRecorder()
+	tracer := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(rec)).Tracer("test")
+	record := func(err error) sdktrace.ReadOnlySpan {
+		_, span := tracer.Start(context.Background(), "op")
+		RecordSpanError(span, err)
+		span.End()
+		spans := rec.Ended()
+		return spans[len(spans)-1]
+	}
+
+	span := record(baseError.SystemStack("OTELX_LEDGER", "ledger down", 4))
+	if span.Status().Code != codes.Error || span.Status().Description != "[OTELX_LEDGER] ledger down" {
+		t.Errorf("status = %+v", span.Status())
+	}
+	attrs := map[attribute.Key]attribute.Value{}
+	for _, kv := range span.Events()[0].Attributes {
+		attrs[kv.Key] = kv.Value
+	}
+	if attrs[KeyCode].AsString() != "OTELX_LEDGER" || !attrs[KeySystem].AsBool() || !strings.Contains(attrs[KeyStacktrace].AsString(), ".go:") {
+		t.Errorf("attrs = %v", attrs)
+	}
+
+	if span := record(baseError.New("OTELX_BAD", "bad")); span.Status().Code != codes.Unset || len(span.Events()) != 1 {
+		t.Errorf("business status = %+v", span.Status())
+	}
+	if span := record(errors.New("raw")); span.Status().Code != codes.Error {
+		t.Errorf("foreign status = %+v", span.Status())
+	}
+	if span := record(nil); len(span.Events()) != 0 {
+		t.Error("nil recorded")
+	}
+}
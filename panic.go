@@ -0,0 +1,76 @@
+package baseError
+
+import (
+	"context"
+	"fmt"
+	"runtime"
+)
+
+const CodePanic = "PANIC"
+
+// FromPanic converts a value returned by recover into a System error whose stack starts at
+// the panic site. It must be called from the deferred function that recovered.
+func FromPanic(r interface{}) *Error {
+	if r == nil {
+		return nil
+	}
+	e := &Error{Code: CodePanic, Msg: fmt.Sprint(r), System: true, stack: panicStack(cfg().PanicStackDepth)}
+	if err, ok := r.(error); ok {
+		e.cause = err
+	}
+	return created(e)
+}
+
+func panicStack(depth int) *stack {
+	if cfg().DisableStacks {
+		return nil
+	}
+	pcs := make([]uintptr, depth+32)
+	n := runtime.Callers(3, pcs)
+	pcs = pcs[:n]
+	for i, pc := range pcs {
+		if fn := runtime.FuncForPC(pc - 1); fn != nil && fn.Name() == "runtime.gopanic" {
+			pcs = pcs[i+1:]
+			break
+		}
+	}
+	if len(pcs) > depth {
+		pcs = pcs[:depth]
+	}
+	var st stack = pcs
+	return &st
+}
+
+func run(fn func() error) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = FromPanic(r)
+		}
+	}()
+	return fn()
+}
+
+// Go runs fn in a new goroutine and delivers its result, or the recovered panic, on the
+// returned channel.
+func Go(fn func() error) <-chan error {
+	ch := make(chan error, 1)
+	go func() {
+		ch <- run(fn)
+		close(ch)
+	}()
+	return ch
+}
+
+// GoCtx is like Go but hands ctx to fn, and skips fn when ctx is already done.
+func GoCtx(ctx context.Context, fn func(ctx context.Context) error) <-chan error {
+	return Go(bindCtx(ctx, fn))
+}
+
+func bindCtx(ctx context.Context, fn func(ctx context.Context) error) func() error {
+	return func() error {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		return fn(ctx)
+	}
+}
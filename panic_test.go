@@ -0,0 +1,34 @@
+package baseError
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func explode() error {
+	panic("boom")
+}
+
+func TestGoRecoversPanic(t *testing.T) {
+	err := <-Go(explode)
+	var e *Error
+	if !errors.As(err, &e) || e.Code != CodePanic || !e.System || e.Msg != "boom" {
+		t.Fatalf("got %v", err)
+	}
+	if top := fmt.Sprintf("%+v", e.StackTrace()[0]); !strings.Contains(top, "explode") {
+		t.Errorf("stack should start at the panic site, got %s", top)
+	}
+
+	if err := <-Go(func() error { return nil }); err != nil {
+		t.Errorf("got %v", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if err := <-GoCtx(ctx, func(context.Context) error { return nil }); err != context.Canceled {
+		t.Errorf("got %v", err)
+	}
+}
@@ -0,0 +1,104 @@
+// Package pkgerrors is a drop-in replacement for github.com/pkg/errors whose functions
+// return *baseError.Error values coded baseError.CodeLegacy, so existing call sites can be
+// migrated by rewriting a single import and then given real codes one by one.
+package pkgerrors
+
+import (
+	stderrors "errors"
+	"fmt"
+
+	baseError "github.com/go-tron/base-error"
+	"github.com/pkg/errors"
+)
+
+type (
+	Frame      = errors.Frame
+	StackTrace = errors.StackTrace
+)
+
+func New(message string) error {
+	return baseError.SystemStack(baseError.CodeLegacy, message, baseError.DefaultStackDepth())
+}
+
+func Errorf(format string, args ...interface{}) error {
+	return baseError.SystemStack(baseError.CodeLegacy, fmt.Sprintf(format, args...), baseError.DefaultStackDepth())
+}
+
+func WithStack(err error) error {
+	if err == nil {
+		return nil
+	}
+	return baseError.WrapStack(baseError.CodeLegacy, err, baseError.DefaultStackDepth())
+}
+
+func Wrap(err error, message string) error {
+	if err == nil {
+		return nil
+	}
+	e := baseError.WrapStack(baseError.CodeLegacy, err, baseError.DefaultStackDepth())
+	e.Msg = message + ": " + err.Error()
+	return e
+}
+
+func Wrapf(err error, format string, args ...interface{}) error {
+	if err == nil {
+		return nil
+	}
+	e := baseError.WrapStack(baseError.CodeLegacy, err, baseError.DefaultStackDepth())
+	e.Msg = fmt.Sprintf(format, args...) + ": " + err.Error()
+	return e
+}
+
+// WithMessage annotates err with message. Annotating a *baseError.Error keeps its code
+// and kind, so a business error stays visible through Sanitize.
+func WithMessage(err error, message string) error {
+	if err == nil {
+		return nil
+	}
+	return withMessage(err, message)
+}
+
+func WithMessagef(err error, format string, args ...interface{}) error {
+	if err == nil {
+		return nil
+	}
+	return withMessage(err, fmt.Sprintf(format, args...))
+}
+
+func withMessage(err error, message string) *baseError.Error {
+	e := baseError.Wrap(baseError.CodeLegacy, err)
+	e.Msg = message + ": " + err.Error()
+	var inner *baseError.Error
+	if stderrors.As(err, &inner) && inner != nil {
+		e.Code, e.System = inner.Code, inner.System
+		if inner == err {
+			e.Msg = message + ": " + inner.Msg
+		}
+	}
+	return e
+}
+
+// Cause differs from errors.Cause only in stopping at errors whose Cause returns nil,
+// which every *baseError.Error without a cause does.
+func Cause(err error) error {
+	for err != nil {
+		c, ok := err.(interface{ Cause() error })
+		if !ok || c.Cause() == nil {
+			break
+		}
+		err = c.Cause()
+	}
+	return err
+}
+
+func Is(err, target error) bool {
+	return stderrors.Is(err, target)
+}
+
+func As(err error, target interface{}) bool {
+	return stderrors.As(err, target)
+}
+
+func Unwrap(err error) error {
+	return stderrors.Unwrap(err)
+}
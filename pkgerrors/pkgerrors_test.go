@@ -0,0 +1,41 @@
+package pkgerrors
+
+import (
+	"io"
+	"testing"
+
+	baseError "github.com/go-tron/base-error"
+)
+
+func TestCompat(t *testing.T) {
+	err := Wrapf(io.EOF, "read %s", "header")
+	if err.Error() != "read header: EOF" {
+		t.Errorf("Error() = %s", err)
+	}
+	if Cause(err) != io.EOF {
+		t.Errorf("Cause() = %v", Cause(err))
+	}
+	if e := New("x"); Cause(e) != e {
+		t.Errorf("Cause() = %v", Cause(e))
+	}
+	if st := err.(interface{ StackTrace() StackTrace }).StackTrace(); len(st) == 0 {
+		t.Error("missing stack")
+	}
+	if Wrap(nil, "x") != nil || WithStack(nil) != nil || WithMessage(nil, "x") != nil {
+		t.Error("wrapping nil should return nil")
+	}
+
+	coded := WithMessage(baseError.New("USER_NOT_FOUND", "user not found"), "load")
+	if code := baseError.CodeOf(coded); code != "USER_NOT_FOUND" {
+		t.Errorf("CodeOf = %s", code)
+	}
+	if s := baseError.Sanitize(coded); s.Code != "USER_NOT_FOUND" || s.Msg != "load: user not found" {
+		t.Errorf("Sanitize = %v", s)
+	}
+	if s := baseError.Sanitize(WithMessagef(io.EOF, "read %s", "header")); s.Code != baseError.CurrentConfig().SanitizedCode {
+		t.Errorf("Sanitize foreign = %v", s)
+	}
+	if code := baseError.CodeOf(New("x")); code != baseError.DefaultWrapCode() {
+		t.Errorf("CodeOf = %s", code)
+	}
+}
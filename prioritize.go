@@ -0,0 +1,57 @@
+package baseError
+
+const FieldSuppressed = "suppressed"
+
+// DefaultPriority ranks errors by severity, System errors above business errors of the
+// same severity.
+func DefaultPriority(err error) int {
+	rank := int(SeverityOf(err)) * 2
+	e := errorOf(err)
+	if e == nil || e.System {
+		rank++
+	}
+	return rank
+}
+
+// Prioritize returns the most significant of errs per Config.Priority (DefaultPriority if
+// unset), earlier errors winning ties. When there are others, a copy of the winner is
+// returned with them attached under the suppressed field as []*Error. Foreign errors are wrapped with their CodeOf. Nil errors are skipped
+// and Prioritize returns nil if nothing is left.
+func Prioritize(errs ...error) *Error {
+	priority := cfg().Priority
+	if priority == nil {
+		priority = DefaultPriority
+	}
+	best, bestRank := -1, 0
+	for i, err := range errs {
+		if isNil(err) {
+			continue
+		}
+		if rank := priority(err); best < 0 || rank > bestRank {
+			best, bestRank = i, rank
+		}
+	}
+	if best < 0 {
+		return nil
+	}
+
+	var rest []*Error
+	for i, err := range errs {
+		if !isNil(err) && i != best {
+			rest = append(rest, asError(err))
+		}
+	}
+	winner := asError(errs[best])
+	if len(rest) > 0 {
+		winner = winner.Copy().WithField(FieldSuppressed, rest)
+	}
+	return winner
+}
+
+func asError(err error) *Error {
+	e := errorOf(err)
+	if e != nil {
+		return e
+	}
+	return Wrap(CodeOf(err), err)
+}
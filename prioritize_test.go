@@ -0,0 +1,44 @@
+package baseError
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestPrioritize(t *testing.T) {
+	invalid := New("BAD_INPUT", "bad input")
+	down := System("DB_DOWN", "db down")
+	critical := New("FRAUD", "fraud").WithSeverity(SeverityCritical)
+
+	got := Prioritize(invalid, nil, down, errors.New("raw"))
+	if got.Code != "DB_DOWN" || got == down {
+		t.Fatalf("got %v", got)
+	}
+	if _, ok := down.Fields()[FieldSuppressed]; ok {
+		t.Error("suppressed list attached to the caller's error")
+	}
+	rest, _ := got.Fields()[FieldSuppressed].([]*Error)
+	if len(rest) != 2 || rest[0] != invalid || rest[1].Code != "UNKNOWN" {
+		t.Errorf("suppressed = %v", rest)
+	}
+	if got := Prioritize(System("A", ""), critical); got.Code != "FRAUD" {
+		t.Errorf("got %v", got)
+	}
+	if Prioritize(nil, nil) != nil {
+		t.Error("expected nil")
+	}
+
+	prev := CurrentConfig()
+	defer Configure(prev)
+	c := CurrentConfig()
+	c.Priority = func(err error) int {
+		if CodeOf(err) == "BAD_INPUT" {
+			return 100
+		}
+		return 0
+	}
+	Configure(c)
+	if got := Prioritize(System("X", ""), New("BAD_INPUT", "")); got.Code != "BAD_INPUT" {
+		t.Errorf("custom priority ignored: %v", got)
+	}
+}
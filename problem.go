@@ -0,0 +1,125 @@
+package baseError
+
+import (
+	"encoding/json"
+	"net/http"
+)
+
+// Problem is an RFC 7807 Problem Details document. Code is carried as an extension
+// member so clients don't have to parse it out of Type; Extensions are further members.
+type Problem struct {
+	Type       string                 `json:"type"`
+	Title      string                 `json:"title"`
+	Status     int                    `json:"status"`
+	Detail     string                 `json:"detail,omitempty"`
+	Instance   string                 `json:"instance,omitempty"`
+	Code       string                 `json:"code"`
+	Extensions map[string]interface{} `json:"-"`
+}
+
+// MarshalJSON flattens Extensions into the document. Extensions never override the
+// standard members.
+func (p *Problem) MarshalJSON() ([]byte, error) {
+	type plain Problem
+	buf, err := json.Marshal((*plain)(p))
+	if err != nil || len(p.Extensions) == 0 {
+		return buf, err
+	}
+	var doc map[string]interface{}
+	if err := json.Unmarshal(buf, &doc); err != nil {
+		return nil, err
+	}
+	for k, v := range p.Extensions {
+		if _, ok := doc[k]; !ok {
+			doc[k] = v
+		}
+	}
+	return json.Marshal(doc)
+}
+
+// ToProblem renders the sanitized form of err as a Problem with the fallback title of its
+// code. Instance is the error reference, if any. The fields of business errors become
+// extensions, minus the volatile ones Equal ignores, internal diagnostics such as the
+// suppressed errors, and values holding errors; System errors expose none.
+func ToProblem(err error) *Problem {
+	return LocalizedProblem(err, "")
+}
+
+// LocalizedProblem is ToProblem with the title registered for locale, falling back to
+// the status text, and business errors' detail localized with Localize. Type depends on
+// the code only, so it stays stable across locales.
+func LocalizedProblem(err error, locale string) *Problem {
+	if isNil(err) {
+		return nil
+	}
+	s := Sanitize(err)
+	status := HTTPStatus(err)
+	title := registryOf(err).Title(s.Code, locale)
+	if title == "" {
+		title = http.StatusText(status)
+	}
+	p := &Problem{
+		Type:   s.cfg().ProblemTypeBase + s.Code,
+		Title:  title,
+		Status: status,
+		Detail: s.Msg,
+		Code:   s.Code,
+	}
+	p.Instance, _ = s.fields[FieldReference].(string)
+
+	e := errorOf(err)
+	if e != nil && !e.System {
+		p.Detail = e.Localize(locale)
+		p.Extensions = e.publicFields()
+	}
+	return p
+}
+
+// internalFields hold diagnostics that never leave the process, even on business errors.
+var internalFields = []string{FieldSuppressed, FieldHints, FieldDetails}
+
+// publicFields returns the JSON form of the fields clients may see: all but the volatile
+// and internal ones, and values that are, or hold, errors, whose messages may be internal.
+func (b *Error) publicFields() map[string]interface{} {
+	ignore := b.privateFields()
+	var out map[string]interface{}
+	for k, v := range b.jsonFields() {
+		if !ignore[k] {
+			if out == nil {
+				out = make(map[string]interface{})
+			}
+			out[k] = v
+		}
+	}
+	return out
+}
+
+// privateFields returns the keys of the fields publicFields leaves out.
+func (b *Error) privateFields() map[string]bool {
+	ignore := make(map[string]bool, len(volatileFields)+len(internalFields))
+	for _, k := range volatileFields {
+		ignore[k] = true
+	}
+	for _, k := range internalFields {
+		ignore[k] = true
+	}
+	for k, v := range b.fields {
+		switch fieldValue(v).(type) {
+		case error, []*Error, []error:
+			ignore[k] = true
+		}
+	}
+	return ignore
+}
+
+// WriteProblem writes err as application/problem+json with the Problem's status.
+func WriteProblem(w http.ResponseWriter, err error) {
+	p := ToProblem(err)
+	if p == nil {
+		return
+	}
+	buf, _ := json.Marshal(p)
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(p.Status)
+	w.Write(buf)
+}
@@ -0,0 +1,55 @@
+package baseError
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestLocalizedProblem(t *testing.T) {
+	RegisterTitle("TEST_ORDER_NOT_FOUND", "", "Order not found")
+	RegisterTitle("TEST_ORDER_NOT_FOUND", "zh", "订单不存在")
+	err := New("TEST_ORDER_NOT_FOUND", "order 7 not found")
+
+	en, zh := LocalizedProblem(err, "en-US"), LocalizedProblem(err, "zh-CN")
+	if en.Title != "Order not found" || zh.Title != "订单不存在" {
+		t.Errorf("titles = %q, %q", en.Title, zh.Title)
+	}
+	if en.Type != "/problems/TEST_ORDER_NOT_FOUND" || en.Type != zh.Type || en.Status != 400 {
+		t.Errorf("got %+v", en)
+	}
+
+	p := LocalizedProblem(System("TEST_DB_DOWN", "dial tcp: refused"), "fr")
+	buf, _ := json.Marshal(p)
+	if want := `{"type":"/problems/INTERNAL_ERROR","title":"Internal Server Error","status":500,"detail":"internal error","instance":"` + p.Instance + `","code":"INTERNAL_ERROR"}`; string(buf) != want {
+		t.Errorf("json = %s", buf)
+	}
+}
+
+func TestWriteProblem(t *testing.T) {
+	rec := httptest.NewRecorder()
+	WriteProblem(rec, New("TEST_QUOTA", "quota exceeded").WithField("limit", 10).WithField(FieldLatency, 5).WithField("code", "spoof"))
+	if rec.Code != 400 || rec.Header().Get("Content-Type") != "application/problem+json" {
+		t.Errorf("status = %d, headers = %v", rec.Code, rec.Header())
+	}
+	if want := `{"code":"TEST_QUOTA","detail":"quota exceeded","limit":10,"status":400,"title":"Bad Request","type":"/problems/TEST_QUOTA"}`; rec.Body.String() != want {
+		t.Errorf("body = %s", rec.Body)
+	}
+
+	p := ToProblem(System("TEST_DB", "dsn=postgres://secret").WithField("dsn", "secret"))
+	if p.Instance == "" || p.Extensions != nil || p.Status != 500 {
+		t.Errorf("got %+v", p)
+	}
+}
+
+func TestProblemHidesInternalFields(t *testing.T) {
+	err := Prioritize(New("TEST_INVALID", "invalid").WithSeverity(SeverityCritical), System("TEST_DB", "dsn=postgres://secret")).
+		WithField(FieldHints, []string{"check the dsn"}).
+		WithField("cause", errors.New("internal")).
+		WithField("field", "email")
+	p := ToProblem(err)
+	if len(p.Extensions) != 1 || p.Extensions["field"] != "email" {
+		t.Errorf("extensions = %v", p.Extensions)
+	}
+}
@@ -0,0 +1,69 @@
+// Package promx exports the rate of constructed errors as a Prometheus counter, labeled
+// by code and system:
+//
+//	c := promx.NewCollector(promx.WithNamespace("billing"))
+//	prometheus.MustRegister(c)
+//	http.Handle("/metrics", promhttp.Handler())
+package promx
+
+import (
+	"strconv"
+
+	baseError "github.com/go-tron/base-error"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+type options struct {
+	namespace   string
+	constLabels prometheus.Labels
+}
+
+type Option func(*options)
+
+func WithNamespace(namespace string) Option {
+	return func(o *options) { o.namespace = namespace }
+}
+
+func WithConstLabels(labels prometheus.Labels) Option {
+	return func(o *options) { o.constLabels = labels }
+}
+
+// Collector counts every *Error built by baseError's constructors and factories,
+// including wraps, in the errors_total counter. It implements prometheus.Collector.
+type Collector struct {
+	errors *prometheus.CounterVec
+	remove func()
+}
+
+// NewCollector starts counting immediately; Close stops it.
+func NewCollector(opts ...Option) *Collector {
+	o := &options{}
+	for _, opt := range opts {
+		opt(o)
+	}
+	c := &Collector{errors: prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace:   o.namespace,
+		Name:        "errors_total",
+		Help:        "Errors constructed or wrapped, by code.",
+		ConstLabels: o.constLabels,
+	}, []string{"code", "system"})}
+	c.remove = baseError.AddCreationHook(c.observe)
+	return c
+}
+
+func (c *Collector) observe(e *baseError.Error) {
+	c.errors.WithLabelValues(e.Code, strconv.FormatBool(e.System)).Inc()
+}
+
+func (c *Collector) Describe(ch chan<- *prometheus.Desc) {
+	c.errors.Describe(ch)
+}
+
+func (c *Collector) Collect(ch chan<- prometheus.Metric) {
+	c.errors.Collect(ch)
+}
+
+// Close unregisters the creation hook. Already collected counts are kept.
+func (c *Collector) Close() {
+	c.remove()
+}
@@ -0,0 +1,33 @@
+package promx
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	baseError "github.com/go-tron/base-error"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+)
+
+func TestCollector(t *testing.T) {
+	c := NewCollector(WithNamespace("app"))
+	reg := prometheus.NewRegistry()
+	reg.MustRegister(c)
+
+	baseError.New("NOT_FOUND", "")
+	baseError.New("NOT_FOUND", "")
+	baseError.Wrap("DB_DOWN", errors.New("refused"))
+	c.Close()
+	baseError.New("NOT_FOUND", "")
+
+	want := `
+# HELP app_errors_total Errors constructed or wrapped, by code.
+# TYPE app_errors_total counter
+app_errors_total{code="DB_DOWN",system="true"} 1
+app_errors_total{code="NOT_FOUND",system="false"} 2
+`
+	if err := testutil.GatherAndCompare(reg, strings.NewReader(want), "app_errors_total"); err != nil {
+		t.Error(err)
+	}
+}
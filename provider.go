@@ -0,0 +1,147 @@
+package baseError
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strings"
+	"sync"
+)
+
+const (
+	FieldProvider       = "provider"
+	FieldUpstreamStatus = "upstream_status"
+	FieldUpstreamBody   = "upstream_body"
+	FieldEndpoint       = "endpoint"
+	FieldProviderCode   = "provider_code"
+
+	maxUpstreamBody = 2048
+	redacted        = "[REDACTED]"
+)
+
+var (
+	sensitiveKeys = []string{"password", "secret", "token", "authorization", "api_key", "apikey", "card", "cvc", "cvv", "ssn"}
+	longDigits    = regexp.MustCompile(`\d{12,19}`)
+)
+
+var providers = struct {
+	sync.RWMutex
+	mappings map[string]map[string]string
+}{mappings: make(map[string]map[string]string)}
+
+// RegisterProviderMapping adds provider codes to the dictionary of provider, mapping each
+// to one of our codes. Later registrations of the same provider code win.
+func RegisterProviderMapping(provider string, mapping map[string]string) {
+	providers.Lock()
+	defer providers.Unlock()
+	m := providers.mappings[provider]
+	if m == nil {
+		m = make(map[string]string, len(mapping))
+		providers.mappings[provider] = m
+	}
+	for from, to := range mapping {
+		m[from] = to
+	}
+}
+
+// TranslateProvider returns our code for a provider's code.
+func TranslateProvider(provider string, providerCode string) (string, bool) {
+	providers.RLock()
+	defer providers.RUnlock()
+	code, ok := providers.mappings[provider][providerCode]
+	return code, ok
+}
+
+// WrapAPIResponse records a failed call to a third-party provider as a System error. The
+// provider's body is kept under upstream_body with the values of sensitive JSON keys
+// and card-like digit runs redacted, cut to 2 KiB. A provider code found in the body is
+// kept under provider_code and, if the provider's mapping knows it, replaces code.
+func WrapAPIResponse(code string, provider string, status int, body []byte) *Error {
+	providerCode := providerCodeOf(body)
+	if mapped, ok := TranslateProvider(provider, providerCode); ok {
+		code = mapped
+	}
+	e := created(&Error{Code: code, Msg: fmt.Sprintf("%s responded %d", provider, status), System: true})
+	e.WithField(FieldProvider, provider).WithField(FieldUpstreamStatus, status)
+	if providerCode != "" {
+		e.WithField(FieldProviderCode, providerCode)
+	}
+	if len(body) > 0 {
+		e.WithField(FieldUpstreamBody, sanitizeBody(body))
+	}
+	return e
+}
+
+// providerCodeOf finds a vendor error code in the usual places: code, error_code,
+// errorCode, a string error, or those keys under an error object.
+func providerCodeOf(body []byte) string {
+	var doc map[string]interface{}
+	if json.Unmarshal(body, &doc) != nil {
+		return ""
+	}
+	if inner, ok := doc["error"].(map[string]interface{}); ok {
+		if code := codeMember(inner); code != "" {
+			return code
+		}
+	}
+	if code := codeMember(doc); code != "" {
+		return code
+	}
+	s, _ := doc["error"].(string)
+	return s
+}
+
+func codeMember(m map[string]interface{}) string {
+	for _, k := range []string{"code", "error_code", "errorCode"} {
+		switch v := m[k].(type) {
+		case string:
+			return v
+		case float64:
+			return fmt.Sprint(v)
+		}
+	}
+	return ""
+}
+
+func (b *Error) WithEndpoint(endpoint string) *Error {
+	return b.WithField(FieldEndpoint, endpoint)
+}
+
+func sanitizeBody(body []byte) string {
+	var doc interface{}
+	s := string(body)
+	if json.Unmarshal(body, &doc) == nil {
+		if buf, err := json.Marshal(redact(doc)); err == nil {
+			s = string(buf)
+		}
+	}
+	return Truncate(longDigits.ReplaceAllString(s, redacted), maxUpstreamBody)
+}
+
+func redact(v interface{}) interface{} {
+	switch x := v.(type) {
+	case map[string]interface{}:
+		for k, val := range x {
+			if sensitiveKey(k) {
+				x[k] = redacted
+			} else {
+				x[k] = redact(val)
+			}
+		}
+	case []interface{}:
+		for i, val := range x {
+			x[i] = redact(val)
+		}
+	}
+	return v
+}
+
+func sensitiveKey(k string) bool {
+	k = strings.ToLower(k)
+	for _, s := range sensitiveKeys {
+		if strings.Contains(k, s) {
+			return true
+		}
+	}
+	return false
+}
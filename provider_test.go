@@ -0,0 +1,51 @@
+package baseError
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestWrapAPIResponse(t *testing.T) {
+	body := []byte(`{"error":{"code":"card_declined","message":"Your card 4242424242424242 was declined","payment_method":{"card_number":"4242"}},"api_key":"sk_live_x"}`)
+	e := WrapAPIResponse("PAYMENT_FAILED", "stripe", 402, body).WithEndpoint("POST /v1/charges")
+	if !e.System || e.Msg != "stripe responded 402" {
+		t.Errorf("got %v", e)
+	}
+	f := e.Fields()
+	if f[FieldProvider] != "stripe" || f[FieldUpstreamStatus] != 402 || f[FieldEndpoint] != "POST /v1/charges" {
+		t.Errorf("fields = %v", f)
+	}
+	got := f[FieldUpstreamBody].(string)
+	for _, leak := range []string{"4242", "sk_live_x"} {
+		if strings.Contains(got, leak) {
+			t.Errorf("body leaks %q: %s", leak, got)
+		}
+	}
+	if !strings.Contains(got, "card_declined") {
+		t.Errorf("body lost the provider code: %s", got)
+	}
+
+	big := WrapAPIResponse("SMS_FAILED", "twilio", 500, []byte(strings.Repeat("é", 2000)))
+	if b := big.Fields()[FieldUpstreamBody].(string); len(b) > maxUpstreamBody {
+		t.Errorf("len = %d", len(b))
+	}
+}
+
+func TestProviderMapping(t *testing.T) {
+	RegisterProviderMapping("test-stripe", map[string]string{"card_declined": "PAYMENT_DECLINED"})
+	RegisterProviderMapping("test-twilio", map[string]string{"21211": "PHONE_INVALID"})
+
+	e := WrapAPIResponse("PAYMENT_FAILED", "test-stripe", 402, []byte(`{"error":{"code":"card_declined"}}`))
+	if e.Code != "PAYMENT_DECLINED" || e.Fields()[FieldProviderCode] != "card_declined" {
+		t.Errorf("got %v %v", e, e.Fields())
+	}
+	if e := WrapAPIResponse("SMS_FAILED", "test-twilio", 400, []byte(`{"code":21211}`)); e.Code != "PHONE_INVALID" {
+		t.Errorf("got %v", e)
+	}
+	if e := WrapAPIResponse("PAYMENT_FAILED", "test-stripe", 402, []byte(`{"error":"rate_limited"}`)); e.Code != "PAYMENT_FAILED" || e.Fields()[FieldProviderCode] != "rate_limited" {
+		t.Errorf("got %v %v", e, e.Fields())
+	}
+	if _, ok := TranslateProvider("test-unknown", "card_declined"); ok {
+		t.Error("mapping leaked across providers")
+	}
+}
@@ -0,0 +1,106 @@
+package baseError
+
+import (
+	"context"
+	"runtime"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// OpUnclassified is the operation under which Sanitize reports the provisional codes of
+// foreign errors to the MetricsRecorder.
+const OpUnclassified = "baseError.unclassified"
+
+// SetProvisionalCodes makes Sanitize, and so every renderer, count foreign errors by
+// their ProvisionalCode with the MetricsRecorder, to find the call sites still returning
+// errors without a code. Clients keep seeing the sanitized code.
+func SetProvisionalCodes(on bool) {
+	updateConfig(func(c *Config) {
+		c.ProvisionalCodes = on
+	})
+}
+
+// ProvisionalCode derives a code for a foreign error from the package of its top frame,
+// e.g. PKG_PAYMENTS_UNCLASSIFIED. Only errors carrying a stack, such as those of
+// github.com/pkg/errors, can be attributed; every stackless error counts as
+// PKG_UNKNOWN_UNCLASSIFIED, since Sanitize typically runs in middleware long after the
+// call that returned it. It returns "" for nil errors and errors carrying an *Error.
+func ProvisionalCode(err error) string {
+	if isNil(err) || errorOf(err) != nil {
+		return ""
+	}
+	return "PKG_" + packageCode(topFunction(err)) + "_UNCLASSIFIED"
+}
+
+func noteUnclassified(c *Config, err error) {
+	if !c.ProvisionalCodes || c.MetricsRecorder == nil {
+		return
+	}
+	c.MetricsRecorder.RecordOperation(context.Background(), OpUnclassified, ProvisionalCode(err), 0)
+}
+
+func topFunction(err error) string {
+	f, _ := innermostFrame(err)
+	return f.Function
+}
+
+// innermostFrame returns the innermost frame of the first github.com/pkg/errors style stack in
+// err's chain.
+func innermostFrame(err error) (runtime.Frame, bool) {
+	for depth := 0; err != nil && depth < maxCauseDepth; depth++ {
+		if st, ok := err.(interface{ StackTrace() errors.StackTrace }); ok {
+			if trace := st.StackTrace(); len(trace) > 0 {
+				f, _ := runtime.CallersFrames([]uintptr{uintptr(trace[0])}).Next()
+				return f, true
+			}
+		}
+		cs := causes(err)
+		if len(cs) == 0 {
+			break
+		}
+		err = cs[0]
+	}
+	return runtime.Frame{}, false
+}
+
+// packageCode turns the package of a qualified function name into an upper-case code
+// segment: "github.com/acme/shop/payments.(*Service).Charge" becomes "PAYMENTS". Major
+// version suffixes are skipped, so "github.com/labstack/echo/v4.New" becomes "ECHO".
+func packageCode(fn string) string {
+	dir, pkg := "", fn
+	if i := strings.LastIndexByte(fn, '/'); i >= 0 {
+		dir, pkg = fn[:i], fn[i+1:]
+	}
+	if i := strings.IndexByte(pkg, '.'); i >= 0 {
+		pkg = pkg[:i]
+	}
+	if isMajorVersion(pkg) && dir != "" {
+		pkg = dir[strings.LastIndexByte(dir, '/')+1:]
+	}
+	if pkg == "" {
+		return "UNKNOWN"
+	}
+	return strings.Map(func(r rune) rune {
+		switch {
+		case 'a' <= r && r <= 'z':
+			return r - 'a' + 'A'
+		case 'A' <= r && r <= 'Z', '0' <= r && r <= '9':
+			return r
+		}
+		return '_'
+	}, pkg)
+}
+
+// isMajorVersion reports whether s is a module major version path element such as "v2".
+func isMajorVersion(s string) bool {
+	if len(s) < 2 || s[0] != 'v' {
+		return false
+	}
+	for _, r := range s[1:] {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+	return true
+}
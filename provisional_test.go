@@ -0,0 +1,59 @@
+package baseError
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	pkgerrors "github.com/pkg/errors"
+)
+
+func TestProvisionalCode(t *testing.T) {
+	if got := ProvisionalCode(errors.New("raw")); got != "PKG_UNKNOWN_UNCLASSIFIED" {
+		t.Errorf("stackless = %q", got)
+	}
+	if got := ProvisionalCode(pkgerrors.New("raw")); got != "PKG_BASE_ERROR_UNCLASSIFIED" {
+		t.Errorf("stack frame = %q", got)
+	}
+	if ProvisionalCode(New("CODED", "")) != "" || ProvisionalCode(nil) != "" {
+		t.Error("coded or nil errors got a provisional code")
+	}
+	for fn, want := range map[string]string{
+		"github.com/acme/shop/payments.(*Service).Charge": "PAYMENTS",
+		"main.main":                  "MAIN",
+		"github.com/acme/my-pkg.Run": "MY_PKG",
+		"github.com/labstack/echo/v4.(*Echo).ServeHTTP": "ECHO",
+		"github.com/acme/shop/v2.Run":                   "SHOP",
+		"github.com/acme/v2ray.Run":                     "V2RAY",
+		"":                                              "UNKNOWN",
+	} {
+		if got := packageCode(fn); got != want {
+			t.Errorf("packageCode(%q) = %q, want %q", fn, got, want)
+		}
+	}
+}
+
+func TestSanitizeCountsUnclassified(t *testing.T) {
+	prev := CurrentConfig()
+	defer Configure(prev)
+	var codes []string
+	SetMetricsRecorder(MetricsRecorderFunc(func(_ context.Context, op string, code string, _ time.Duration) {
+		if op == OpUnclassified {
+			codes = append(codes, code)
+		}
+	}))
+
+	Sanitize(pkgerrors.New("raw"))
+	if len(codes) != 0 {
+		t.Fatal("counted while disabled")
+	}
+	SetProvisionalCodes(true)
+	if s := Sanitize(pkgerrors.New("raw")); s.Code != "INTERNAL_ERROR" {
+		t.Errorf("public code = %s", s.Code)
+	}
+	Sanitize(System("CODED", ""))
+	if len(codes) != 1 || codes[0] != "PKG_BASE_ERROR_UNCLASSIFIED" {
+		t.Errorf("codes = %v", codes)
+	}
+}
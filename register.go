@@ -0,0 +1,108 @@
+package baseError
+
+import (
+	"errors"
+	"fmt"
+	"runtime"
+	"sort"
+	"strings"
+)
+
+var ErrDuplicateCode = errors.New("baseError: duplicate code")
+
+// Registration records where a code was declared and its message template.
+type Registration struct {
+	Code     string `json:"code"`
+	Template string `json:"template,omitempty"`
+	Site     string `json:"site,omitempty"`
+}
+
+// Register declares code as owned by the calling site. Declaring a code a second time
+// from another site fails with ErrDuplicateCode; repeating a declaration from the same
+// site, as a test run with -count does, is allowed. Factories register their codes
+// themselves, and panic on duplicates in strict mode.
+func (r *Registry) Register(code string, template string) error {
+	return r.register(code, template, callerSite())
+}
+
+// MustRegister is Register that panics on duplicates.
+func (r *Registry) MustRegister(code string, template string) {
+	if err := r.register(code, template, callerSite()); err != nil {
+		panic(err)
+	}
+}
+
+func (r *Registry) register(code string, template string, site string) error {
+	code = r.Qualify(code)
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if prev, ok := r.registered[code]; ok && prev.Site != site {
+		return fmt.Errorf("%w %s: declared at %s and %s", ErrDuplicateCode, code, prev.Site, site)
+	}
+	if r.registered == nil {
+		r.registered = make(map[string]Registration)
+	}
+	r.registered[code] = Registration{Code: code, Template: template, Site: site}
+	return nil
+}
+
+// List returns every code declared with Register or known to r and its mounted
+// registries, sorted, with its template: the registered one, else the declared one.
+func (r *Registry) List() []Registration {
+	byCode := make(map[string]Registration)
+	r.mu.RLock()
+	mounts := r.mounts
+	for code, reg := range r.registered {
+		byCode[code] = reg
+	}
+	for code, info := range r.codes {
+		reg := byCode[code]
+		reg.Code = code
+		if info.Template != "" {
+			reg.Template = info.Template
+		}
+		byCode[code] = reg
+	}
+	r.mu.RUnlock()
+	for _, sub := range mounts {
+		for _, reg := range sub.List() {
+			if _, ok := byCode[reg.Code]; !ok {
+				byCode[reg.Code] = reg
+			}
+		}
+	}
+
+	out := make([]Registration, 0, len(byCode))
+	for _, reg := range byCode {
+		out = append(out, reg)
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].Code < out[j].Code })
+	return out
+}
+
+// registerFactory registers the code of a factory being built, taking the template
+// given to it, if any.
+func registerFactory(r *Registry, c *Config, code string, arg []string) {
+	var template string
+	if len(arg) > 1 {
+		template = arg[1]
+	}
+	if err := r.register(code, template, callerSite()); err != nil && c.Strict {
+		panic(err)
+	}
+}
+
+// callerSite returns file:line of the first caller outside this package's sources, like
+// Callers does for stacks.
+func callerSite() string {
+	for i := 2; i < 15; i++ {
+		_, file, line, ok := runtime.Caller(i)
+		if !ok {
+			break
+		}
+		if !inSourceDirs(file) || strings.HasSuffix(file, "_test.go") {
+			return fmt.Sprintf("%s:%d", file, line)
+		}
+	}
+	return ""
+}
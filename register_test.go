@@ -0,0 +1,56 @@
+package baseError
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestRegister(t *testing.T) {
+	r := NewRegistry()
+	for i := 0; i < 2; i++ {
+		if err := r.Register("ORDER_NOT_FOUND", "order {} not found"); err != nil {
+			t.Fatalf("same site: %v", err)
+		}
+	}
+	err := r.Register("ORDER_NOT_FOUND", "")
+	if !errors.Is(err, ErrDuplicateCode) || !strings.Contains(err.Error(), "register_test.go") {
+		t.Errorf("err = %v", err)
+	}
+
+	sub := NewSubRegistry("billing")
+	sub.MustRegister("DECLINED", "card declined")
+	r.Mount(sub)
+	r.SetTemplate("CART_EMPTY", "cart is empty")
+
+	var got []string
+	for _, reg := range r.List() {
+		got = append(got, reg.Code+"="+reg.Template)
+	}
+	if want := "CART_EMPTY=cart is empty ORDER_NOT_FOUND=order {} not found billing.DECLINED=card declined"; strings.Join(got, " ") != want {
+		t.Errorf("List = %v", got)
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Error("MustRegister did not panic on a duplicate")
+		}
+	}()
+	sub.MustRegister("billing.DECLINED", "")
+}
+
+func TestFactoryDuplicate(t *testing.T) {
+	prev := CurrentConfig()
+	defer Configure(prev)
+
+	Factory("REGISTER_DUP", "first")
+	Factory("REGISTER_DUP", "second")
+
+	SetStrict(true)
+	defer func() {
+		if r := recover(); r == nil || !errors.Is(r.(error), ErrDuplicateCode) {
+			t.Errorf("recover = %v", r)
+		}
+	}()
+	SystemFactory("REGISTER_DUP")
+}
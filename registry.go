@@ -0,0 +1,333 @@
+package baseError
+
+import (
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+)
+
+type CodeInfo struct {
+	Code       string            `json:"code"`
+	LogLevel   Level             `json:"log_level,omitempty"`
+	PublicCode string            `json:"public_code,omitempty"`
+	PublicMsg  string            `json:"public_msg,omitempty"`
+	Retryable  bool              `json:"retryable,omitempty"`
+	Severity   Severity          `json:"severity,omitempty"`
+	Template   string            `json:"template,omitempty"`
+	Messages   map[string]string `json:"messages,omitempty"`
+	Titles     map[string]string `json:"titles,omitempty"`
+	HTTPStatus int               `json:"http_status,omitempty"`
+}
+
+type Registry struct {
+	mu     sync.RWMutex
+	prefix string
+	codes  map[string]*CodeInfo
+	mounts []*Registry
+	depths map[Severity]int
+
+	registered map[string]Registration
+}
+
+func NewRegistry() *Registry {
+	return &Registry{codes: make(map[string]*CodeInfo)}
+}
+
+// NewSubRegistry returns a registry for one domain whose codes live under prefix: codes
+// passed to its setters are qualified as prefix.CODE unless they already are.
+func NewSubRegistry(prefix string) *Registry {
+	return &Registry{prefix: prefix, codes: make(map[string]*CodeInfo)}
+}
+
+var DefaultRegistry = NewRegistry()
+
+func (r *Registry) Prefix() string {
+	return r.prefix
+}
+
+func (r *Registry) Qualify(code string) string {
+	if r.prefix == "" || strings.HasPrefix(code, r.prefix+".") {
+		return code
+	}
+	return r.prefix + "." + code
+}
+
+// Lookup finds code in r or in any registry mounted on it.
+func (r *Registry) Lookup(code string) (CodeInfo, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	if info, ok := r.codes[code]; ok {
+		return info.copy(), true
+	}
+	for _, sub := range r.mounts {
+		if info, ok := sub.Lookup(code); ok {
+			return info, true
+		}
+	}
+	return CodeInfo{}, false
+}
+
+// Codes returns every code known to r and its mounted registries, sorted.
+func (r *Registry) Codes() []string {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return r.codesLocked()
+}
+
+// codesLocked is Codes for a caller holding r.mu.
+func (r *Registry) codesLocked() []string {
+	codes := make([]string, 0, len(r.codes))
+	for code := range r.codes {
+		codes = append(codes, code)
+	}
+	for _, sub := range r.mounts {
+		codes = append(codes, sub.Codes()...)
+	}
+	sort.Strings(codes)
+	return codes
+}
+
+// Mount makes sub's codes visible through r, typically from a domain package's init. It
+// fails if sub's prefix is already mounted or any of its codes is already known to r.
+func (r *Registry) Mount(sub *Registry) error {
+	if sub == r || sub.reaches(r) {
+		return fmt.Errorf("baseError: mounting registry %q would create a cycle", sub.prefix)
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for _, m := range r.mounts {
+		if m == sub || (sub.prefix != "" && m.prefix == sub.prefix) {
+			return fmt.Errorf("baseError: registry prefix %q is already mounted", sub.prefix)
+		}
+	}
+	known := make(map[string]bool)
+	for _, code := range r.codesLocked() {
+		known[code] = true
+	}
+	var collisions []string
+	for _, code := range sub.Codes() {
+		if known[code] {
+			collisions = append(collisions, code)
+		}
+	}
+	if len(collisions) > 0 {
+		return fmt.Errorf("baseError: registry %q redefines codes %s", sub.prefix, strings.Join(collisions, ", "))
+	}
+	r.mounts = append(r.mounts, sub)
+	return nil
+}
+
+func (r *Registry) reaches(target *Registry) bool {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	for _, sub := range r.mounts {
+		if sub == target || sub.reaches(target) {
+			return true
+		}
+	}
+	return false
+}
+
+func (info *CodeInfo) copy() CodeInfo {
+	c := *info
+	c.Messages = copyStrings(info.Messages)
+	c.Titles = copyStrings(info.Titles)
+	return c
+}
+
+func copyStrings(m map[string]string) map[string]string {
+	if m == nil {
+		return nil
+	}
+	c := make(map[string]string, len(m))
+	for k, v := range m {
+		c[k] = v
+	}
+	return c
+}
+
+func (r *Registry) update(code string, fn func(info *CodeInfo)) {
+	code = r.Qualify(code)
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	info, ok := r.codes[code]
+	if !ok {
+		info = &CodeInfo{Code: code}
+		r.codes[code] = info
+	}
+	fn(info)
+}
+
+func (r *Registry) SetLogLevel(code string, level Level) {
+	r.update(code, func(info *CodeInfo) {
+		info.LogLevel = level
+	})
+}
+
+// LogLevel returns the level err should be logged at. Codes without a registered level
+// default to LevelError for System and foreign errors and LevelWarn otherwise.
+func (r *Registry) LogLevel(err error) Level {
+	if isNil(err) {
+		return 0
+	}
+	e := errorOf(err)
+	if e == nil {
+		return LevelError
+	}
+	if info, ok := r.Lookup(e.Code); ok && info.LogLevel != 0 {
+		return info.LogLevel
+	}
+	if e.System {
+		return LevelError
+	}
+	return LevelWarn
+}
+
+// SetPublic sets the code and message Sanitize exposes in place of a System error with code.
+func (r *Registry) SetPublic(code string, publicCode string, publicMsg string) {
+	r.update(code, func(info *CodeInfo) {
+		info.PublicCode = publicCode
+		info.PublicMsg = publicMsg
+	})
+}
+
+func (r *Registry) SetRetryable(code string, retryable bool) {
+	r.update(code, func(info *CodeInfo) {
+		info.Retryable = retryable
+	})
+}
+
+func (r *Registry) SetSeverity(code string, severity Severity) {
+	r.update(code, func(info *CodeInfo) {
+		info.Severity = severity
+	})
+}
+
+// SetTemplate records the message template of code, in the {} placeholder syntax of
+// Factory.
+func (r *Registry) SetTemplate(code string, template string) {
+	r.update(code, func(info *CodeInfo) {
+		info.Template = template
+	})
+}
+
+// SetMessage records the template of code for one locale.
+func (r *Registry) SetMessage(code string, locale string, template string) {
+	r.update(code, func(info *CodeInfo) {
+		if info.Messages == nil {
+			info.Messages = make(map[string]string)
+		}
+		info.Messages[locale] = template
+	})
+}
+
+// SetTitle records the Problem Details title of code for locale; the empty locale is the
+// fallback for locales without a title of their own.
+func (r *Registry) SetTitle(code string, locale string, title string) {
+	r.update(code, func(info *CodeInfo) {
+		if info.Titles == nil {
+			info.Titles = make(map[string]string)
+		}
+		info.Titles[locale] = title
+	})
+}
+
+// Title returns the title of code for locale, trying the locale, then its base language
+// ("zh" for "zh-CN"), then the fallback title.
+func (r *Registry) Title(code string, locale string) string {
+	info, ok := r.Lookup(code)
+	if !ok {
+		return ""
+	}
+	for _, l := range []string{locale, baseLanguage(locale), ""} {
+		if t, ok := info.Titles[l]; ok {
+			return t
+		}
+	}
+	return ""
+}
+
+func baseLanguage(locale string) string {
+	if i := strings.IndexAny(locale, "-_"); i > 0 {
+		return locale[:i]
+	}
+	return locale
+}
+
+func (r *Registry) SetHTTPStatus(code string, status int) {
+	r.update(code, func(info *CodeInfo) {
+		info.HTTPStatus = status
+	})
+}
+
+// HTTPStatus returns the status registered for err's code, else 500 for System and
+// foreign errors and 400 otherwise. It returns 200 for nil.
+func (r *Registry) HTTPStatus(err error) int {
+	if isNil(err) {
+		return http.StatusOK
+	}
+	e := errorOf(err)
+	if e == nil {
+		return http.StatusInternalServerError
+	}
+	if info, ok := r.Lookup(e.Code); ok && info.HTTPStatus != 0 {
+		return info.HTTPStatus
+	}
+	if e.System {
+		return http.StatusInternalServerError
+	}
+	return http.StatusBadRequest
+}
+
+func Mount(sub *Registry) error {
+	return DefaultRegistry.Mount(sub)
+}
+
+func RegisterLogLevel(code string, level Level) {
+	DefaultRegistry.SetLogLevel(code, level)
+}
+
+func RegisterPublic(code string, publicCode string, publicMsg string) {
+	DefaultRegistry.SetPublic(code, publicCode, publicMsg)
+}
+
+func RegisterRetryable(code string, retryable bool) {
+	DefaultRegistry.SetRetryable(code, retryable)
+}
+
+func RegisterSeverity(code string, severity Severity) {
+	DefaultRegistry.SetSeverity(code, severity)
+}
+
+func RegisterTemplate(code string, template string) {
+	DefaultRegistry.SetTemplate(code, template)
+}
+
+func RegisterMessage(code string, locale string, template string) {
+	DefaultRegistry.SetMessage(code, locale, template)
+}
+
+func RegisterTitle(code string, locale string, title string) {
+	DefaultRegistry.SetTitle(code, locale, title)
+}
+
+func RegisterHTTPStatus(code string, status int) {
+	DefaultRegistry.SetHTTPStatus(code, status)
+}
+
+func HTTPStatus(err error) int {
+	return registryOf(err).HTTPStatus(err)
+}
+
+func LogLevel(err error) Level {
+	return registryOf(err).LogLevel(err)
+}
+
+// ShouldLog reports whether err is to be logged by a logger whose minimum level is level.
+// Suppressed errors are never logged.
+func ShouldLog(err error, level Level) bool {
+	return !isNil(err) && LogLevel(err) >= level && !Suppressed(err)
+}
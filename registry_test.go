@@ -0,0 +1,116 @@
+package baseError
+
+import (
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+)
+
+func TestShouldLog(t *testing.T) {
+	r := NewRegistry()
+	r.SetLogLevel("NOT_FOUND", LevelInfo)
+
+	if l := r.LogLevel(New("NOT_FOUND", "")); l != LevelInfo {
+		t.Errorf("NOT_FOUND level = %s", l)
+	}
+	if l := r.LogLevel(New("BAD_INPUT", "")); l != LevelWarn {
+		t.Errorf("BAD_INPUT level = %s", l)
+	}
+	if l := r.LogLevel(System("DB", "")); l != LevelError {
+		t.Errorf("DB level = %s", l)
+	}
+	if l := r.LogLevel(errors.New("raw")); l != LevelError {
+		t.Errorf("foreign level = %s", l)
+	}
+
+	RegisterLogLevel("TEST_EXPECTED", LevelDebug)
+	if ShouldLog(New("TEST_EXPECTED", ""), LevelInfo) {
+		t.Error("debug level error should not be logged at info")
+	}
+	if !ShouldLog(System("TEST_SYSTEM", ""), LevelError) {
+		t.Error("system error should be logged at error")
+	}
+	if ShouldLog(nil, LevelDebug) {
+		t.Error("nil should not be logged")
+	}
+}
+
+func TestMount(t *testing.T) {
+	root := NewRegistry()
+	root.SetLogLevel("INTERNAL", LevelError)
+
+	auth := NewSubRegistry("AUTH")
+	auth.SetLogLevel("EXPIRED", LevelInfo)
+	billing := NewSubRegistry("BILLING")
+	billing.SetRetryable("BILLING.GATEWAY_DOWN", true)
+
+	if err := root.Mount(auth); err != nil {
+		t.Fatal(err)
+	}
+	if err := root.Mount(billing); err != nil {
+		t.Fatal(err)
+	}
+	if info, ok := root.Lookup("AUTH.EXPIRED"); !ok || info.LogLevel != LevelInfo {
+		t.Errorf("AUTH.EXPIRED = %+v %v", info, ok)
+	}
+	if got := root.Codes(); len(got) != 3 || got[0] != "AUTH.EXPIRED" || got[1] != "BILLING.GATEWAY_DOWN" {
+		t.Errorf("codes = %v", got)
+	}
+	if l := root.LogLevel(New("AUTH.EXPIRED", "")); l != LevelInfo {
+		t.Errorf("level = %s", l)
+	}
+
+	if err := root.Mount(NewSubRegistry("AUTH")); err == nil {
+		t.Error("expected prefix collision")
+	}
+	dup := NewSubRegistry("")
+	dup.SetLogLevel("INTERNAL", LevelWarn)
+	if err := root.Mount(dup); err == nil {
+		t.Error("expected code collision")
+	}
+	if err := auth.Mount(root); err == nil {
+		t.Error("expected cycle")
+	}
+}
+
+func TestMountConcurrent(t *testing.T) {
+	root := NewRegistry()
+	var wg sync.WaitGroup
+	var mounted int32
+	for i := 0; i < 8; i++ {
+		sub := NewSubRegistry("")
+		sub.SetLogLevel("SHARED", LevelInfo)
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if root.Mount(sub) == nil {
+				atomic.AddInt32(&mounted, 1)
+			}
+		}()
+	}
+	wg.Wait()
+	if mounted != 1 {
+		t.Errorf("mounted %d registries defining SHARED", mounted)
+	}
+}
+
+func TestHTTPStatus(t *testing.T) {
+	RegisterHTTPStatus("TEST_USER_NOT_FOUND", 404)
+	RegisterHTTPStatus("TEST_UPSTREAM_TIMEOUT", 504)
+	for err, want := range map[error]int{
+		nil:                                 200,
+		New("TEST_USER_NOT_FOUND", ""):      404,
+		System("TEST_UPSTREAM_TIMEOUT", ""): 504,
+		New("TEST_UNREGISTERED", ""):        400,
+		System("TEST_UNREGISTERED", ""):     500,
+		errors.New("raw"):                   500,
+	} {
+		if got := HTTPStatus(err); got != want {
+			t.Errorf("HTTPStatus(%v) = %d, want %d", err, got, want)
+		}
+	}
+	if p := ToProblem(New("TEST_USER_NOT_FOUND", "")); p.Status != 404 || p.Title != "Not Found" {
+		t.Errorf("problem = %+v", p)
+	}
+}
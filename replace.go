@@ -0,0 +1,30 @@
+package baseError
+
+const FieldPreviousCode = "previous_code"
+
+// ReplaceCode returns a copy of err with code newCode, keeping its message, kind, cause,
+// stack and fields, and the old code under the previous_code field. Boundary layers use
+// it to re-categorize upstream errors without wrapping them and capturing another stack.
+// A foreign err, or one whose outermost error isn't an *Error, is wrapped instead.
+func ReplaceCode(err error, newCode string) *Error {
+	if isNil(err) {
+		return nil
+	}
+	e, ok := err.(*Error)
+	if !ok {
+		out := Wrap(newCode, err)
+		if inner := errorOf(err); inner != nil {
+			out.WithField(FieldPreviousCode, inner.Code)
+		}
+		return out
+	}
+	out := *e
+	out.Code = newCode
+	out.fields = e.Fields()
+	out.safeDetails = e.SafeDetails()
+	out.debugDetails = e.DebugDetails()
+	if _, ok := out.fields[FieldPreviousCode]; !ok {
+		out.fields[FieldPreviousCode] = e.Code
+	}
+	return created(&out)
+}
@@ -0,0 +1,34 @@
+package baseError
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+)
+
+func TestReplaceCode(t *testing.T) {
+	cause := errors.New("no rows")
+	e := WrapStack("REPLACE_DB_NOT_FOUND", cause, 4).WithField("table", "orders").WithSafeDetails("id=1")
+	got := ReplaceCode(e, "REPLACE_ORDER_NOT_FOUND")
+
+	if got.Code != "REPLACE_ORDER_NOT_FOUND" || got.Msg != e.Msg || !got.System || got.Cause() != cause || got.Stack() != e.Stack() {
+		t.Errorf("got %+v", got)
+	}
+	if f := got.Fields(); f["table"] != "orders" || f[FieldPreviousCode] != "REPLACE_DB_NOT_FOUND" {
+		t.Errorf("fields = %v", f)
+	}
+	if e.Code != "REPLACE_DB_NOT_FOUND" || len(e.Fields()) != 1 || len(got.SafeDetails()) != 1 {
+		t.Error("original changed")
+	}
+	if again := ReplaceCode(got, "REPLACE_GONE"); again.Fields()[FieldPreviousCode] != "REPLACE_DB_NOT_FOUND" {
+		t.Error("first previous code not kept")
+	}
+
+	wrapped := ReplaceCode(fmt.Errorf("load: %w", e), "REPLACE_LOAD")
+	if wrapped.Code != "REPLACE_LOAD" || wrapped.Fields()[FieldPreviousCode] != "REPLACE_DB_NOT_FOUND" || !errors.Is(wrapped, cause) {
+		t.Errorf("wrapped = %v %v", wrapped, wrapped.Fields())
+	}
+	if ReplaceCode(nil, "X") != nil {
+		t.Error("nil replaced")
+	}
+}
@@ -0,0 +1,39 @@
+package baseError
+
+import (
+	"context"
+	"errors"
+)
+
+// WithRetryable overrides the retryability registered for the error's code.
+func (b *Error) WithRetryable(retryable bool) *Error {
+	b.retryable = &retryable
+	return b
+}
+
+// IsRetryable reports whether the operation that produced err may succeed if repeated.
+// The first *Error in the chain decides, through its own override or its code's
+// registration; otherwise timeouts and errors reporting Temporary() are retryable.
+func IsRetryable(err error) bool {
+	if isNil(err) {
+		return false
+	}
+	e := errorOf(err)
+	if e != nil {
+		if e.retryable != nil {
+			return *e.retryable
+		}
+		if info, ok := e.registry().Lookup(e.Code); ok {
+			return info.Retryable
+		}
+	}
+	if errors.Is(err, context.DeadlineExceeded) {
+		return true
+	}
+	var t interface{ Temporary() bool }
+	if errors.As(err, &t) && t.Temporary() {
+		return true
+	}
+	var to interface{ Timeout() bool }
+	return errors.As(err, &to) && to.Timeout()
+}
@@ -0,0 +1,29 @@
+package baseError
+
+import (
+	"context"
+	"fmt"
+	"testing"
+)
+
+func TestIsRetryable(t *testing.T) {
+	RegisterRetryable("TEST_UPSTREAM_BUSY", true)
+
+	cases := []struct {
+		err  error
+		want bool
+	}{
+		{New("TEST_UPSTREAM_BUSY", ""), true},
+		{New("TEST_UPSTREAM_BUSY", "").WithRetryable(false), false},
+		{New("BAD_INPUT", ""), false},
+		{New("BAD_INPUT", "").WithRetryable(true), true},
+		{fmt.Errorf("call: %w", context.DeadlineExceeded), true},
+		{context.Canceled, false},
+		{nil, false},
+	}
+	for _, c := range cases {
+		if got := IsRetryable(c.err); got != c.want {
+			t.Errorf("IsRetryable(%v) = %v", c.err, got)
+		}
+	}
+}
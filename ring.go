@@ -0,0 +1,71 @@
+package baseError
+
+import (
+	"sync"
+	"time"
+)
+
+// RingEntry is one error kept by a Ring with the time it was added.
+type RingEntry struct {
+	Err error
+	At  time.Time
+}
+
+// Ring keeps the most recent errors in a fixed-size buffer, e.g. for crash reports of
+// long-running agents. Enable it with AddHook(r.Hook()).
+type Ring struct {
+	mu      sync.Mutex
+	entries []RingEntry
+	next    int
+	full    bool
+}
+
+func NewRing(size int) *Ring {
+	if size <= 0 {
+		size = 64
+	}
+	return &Ring{entries: make([]RingEntry, size)}
+}
+
+func (r *Ring) Add(err error) {
+	if isNil(err) {
+		return
+	}
+	at := Now()
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.entries[r.next] = RingEntry{Err: err, At: at}
+	r.next = (r.next + 1) % len(r.entries)
+	if r.next == 0 {
+		r.full = true
+	}
+}
+
+func (r *Ring) Hook() Hook {
+	return r.Add
+}
+
+// Recent returns the kept errors, newest first.
+func (r *Ring) Recent() []RingEntry {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	n := r.next
+	if r.full {
+		n = len(r.entries)
+	}
+	out := make([]RingEntry, 0, n)
+	for i := 1; i <= n; i++ {
+		out = append(out, r.entries[(r.next-i+len(r.entries))%len(r.entries)])
+	}
+	return out
+}
+
+// Last returns the newest kept error whose severity is at least min.
+func (r *Ring) Last(min Severity) (RingEntry, bool) {
+	for _, entry := range r.Recent() {
+		if SeverityOf(entry.Err) >= min {
+			return entry, true
+		}
+	}
+	return RingEntry{}, false
+}
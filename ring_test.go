@@ -0,0 +1,32 @@
+package baseError
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestRing(t *testing.T) {
+	r := NewRing(3)
+	if _, ok := r.Last(SeverityInfo); ok || len(r.Recent()) != 0 {
+		t.Fatal("empty ring has entries")
+	}
+	r.Add(New("RING_A", "").WithSeverity(SeverityCritical))
+	r.Add(nil)
+	r.Add(New("RING_B", ""))
+	if got := r.Recent(); len(got) != 2 || CodeOf(got[0].Err) != "RING_B" {
+		t.Errorf("recent = %v", got)
+	}
+	r.Add(errors.New("raw"))
+	r.Add(New("RING_C", ""))
+	got := r.Recent()
+	if len(got) != 3 || CodeOf(got[0].Err) != "RING_C" || CodeOf(got[2].Err) != "RING_B" {
+		t.Errorf("recent = %v", got)
+	}
+	if _, ok := r.Last(SeverityCritical); ok {
+		t.Error("evicted critical error returned")
+	}
+	r.Add(System("RING_D", "").WithSeverity(SeverityCritical))
+	if last, ok := r.Last(SeverityCritical); !ok || CodeOf(last.Err) != "RING_D" || last.At.IsZero() {
+		t.Errorf("last = %v %v", last, ok)
+	}
+}
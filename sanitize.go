@@ -0,0 +1,64 @@
+package baseError
+
+const FieldReference = "ref"
+
+// Sanitize returns a copy of err that is safe to send to clients. Business errors keep
+// their code, message and safe details. System and foreign errors are replaced by their
+// registered public code and message (Config.SanitizedCode and SanitizedMsg by default) plus a
+// reference ID; use SanitizeLogged to log an error carrying the same ID. Causes, stacks,
+// fields and debug details are always dropped, and err itself is never modified.
+func Sanitize(err error) *Error {
+	out, _ := sanitize(err)
+	return out
+}
+
+// SanitizeLogged returns Sanitize(err) and the error to log in place of err: a copy of the
+// *Error in err's chain carrying the reference ID of the sanitized result when it had
+// none, or err itself.
+func SanitizeLogged(err error) (*Error, error) {
+	out, stamped := sanitize(err)
+	if stamped != nil {
+		return out, stamped
+	}
+	return out, err
+}
+
+// sanitize also returns the copy of the System *Error of err stamped with the new reference
+// ID, or nil if none was made.
+func sanitize(err error) (out *Error, stamped *Error) {
+	if isNil(err) {
+		return nil, nil
+	}
+	e := errorOf(err)
+	if e != nil && !e.System {
+		return &Error{Code: e.Code, Msg: e.Msg, safeDetails: e.SafeDetails(), sanitized: true}, nil
+	}
+
+	c, r := cfg(), DefaultRegistry
+	if e != nil {
+		c, r = e.cfg(), e.registry()
+	} else {
+		noteUnclassified(c, err)
+	}
+	out = &Error{Code: c.SanitizedCode, Msg: c.SanitizedMsg, System: true, sanitized: true}
+	if info, ok := r.Lookup(CodeOf(err)); ok && info.PublicCode != "" {
+		out.Code = info.PublicCode
+		out.Msg = info.PublicMsg
+	}
+	ref := newID()
+	if e != nil {
+		if existing, ok := e.fields[FieldReference].(string); ok {
+			ref = existing
+		} else {
+			stamped = e.Copy().WithField(FieldReference, ref)
+		}
+	}
+	return out.WithField(FieldReference, ref), stamped
+}
+
+func (b *Error) Sanitized() bool {
+	if b == nil {
+		return false
+	}
+	return b.sanitized
+}
@@ -0,0 +1,42 @@
+package baseError
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+)
+
+func TestSanitize(t *testing.T) {
+	biz := New("USER_NOT_FOUND", "user not found").WithSafeDetails("id=7").WithDebugDetails("shard=3")
+	if s := Sanitize(biz); s.Code != "USER_NOT_FOUND" || len(s.SafeDetails()) != 1 || len(s.DebugDetails()) != 0 || !s.Sanitized() {
+		t.Errorf("got %+v", s)
+	}
+
+	sys := WrapStack("DB_QUERY", errors.New("pq: relation \"users\" does not exist"), 5)
+	s, logged := SanitizeLogged(sys)
+	if s.Code != "INTERNAL_ERROR" || s.Msg != "internal error" || s.Stack() != nil || s.Cause() != nil {
+		t.Fatalf("got %+v", s)
+	}
+	ref := s.Fields()[FieldReference]
+	if ref == nil || errorOf(logged).Fields()[FieldReference] != ref {
+		t.Errorf("reference not shared: %v %v", ref, errorOf(logged).Fields()[FieldReference])
+	}
+	if _, ok := sys.Fields()[FieldReference]; ok {
+		t.Error("Sanitize modified its argument")
+	}
+	if again := Sanitize(sys).Fields()[FieldReference]; again == ref {
+		t.Error("reference reused across calls")
+	}
+	buf, _ := json.Marshal(s)
+	if want := `{"code":"INTERNAL_ERROR","msg":"internal error","ref":"` + ref.(string) + `"}`; string(buf) != want {
+		t.Errorf("json = %s", buf)
+	}
+
+	RegisterPublic("TEST_PAYMENT_GATEWAY", "PAYMENT_UNAVAILABLE", "payments are temporarily unavailable")
+	if s := Sanitize(System("TEST_PAYMENT_GATEWAY", "stripe: 502")); s.Code != "PAYMENT_UNAVAILABLE" {
+		t.Errorf("got %v", s)
+	}
+	if s := Sanitize(errors.New("raw")); s.Code != "INTERNAL_ERROR" {
+		t.Errorf("got %v", s)
+	}
+}
@@ -0,0 +1,145 @@
+package baseError
+
+import (
+	"sync/atomic"
+)
+
+// Scope is an isolated instance of the package with its own registry, hooks and
+// configuration, for libraries that must not depend on, or change, the settings of the
+// application embedding them. Errors built by a Scope remember it: package functions
+// such as Report, LogLevel, Sanitize or MarshalJSON honor the scope's settings for them.
+type Scope struct {
+	name     string
+	registry *Registry
+	config   atomic.Pointer[Config]
+	ctors    constructors
+}
+
+// NewScope returns a scope starting from DefaultConfig and an empty registry.
+func NewScope(name string) *Scope {
+	s := &Scope{name: name, registry: NewRegistry()}
+	s.config.Store(defaultConfig())
+	s.ctors = constructors{
+		registry: s.registry,
+		depths:   s.registry,
+		config:   s.config.Load,
+		code:     func(code string) string { return code },
+		finish:   func(e *Error) { e.scope = s },
+	}
+	return s
+}
+
+func (s *Scope) Name() string {
+	return s.name
+}
+
+func (s *Scope) Registry() *Registry {
+	return s.registry
+}
+
+func (s *Scope) Configure(c Config) {
+	s.config.Store(c.clone())
+}
+
+func (s *Scope) CurrentConfig() Config {
+	return *s.config.Load().clone()
+}
+
+func (s *Scope) AddHook(h Hook) {
+	for {
+		old := s.config.Load()
+		c := old.clone()
+		c.Hooks = append(c.Hooks, h)
+		if s.config.CompareAndSwap(old, c) {
+			return
+		}
+	}
+}
+
+func (s *Scope) New(code string, msg string) *Error {
+	return s.ctors.new(code, msg, false)
+}
+
+func (s *Scope) NewStack(code string, msg string, depth int) *Error {
+	return s.ctors.newStack(code, msg, false, depth)
+}
+
+func (s *Scope) System(code string, msg string) *Error {
+	return s.ctors.new(code, msg, true)
+}
+
+func (s *Scope) SystemStack(code string, msg string, depth int) *Error {
+	return s.ctors.newStack(code, msg, true, depth)
+}
+
+func (s *Scope) Factory(arg ...string) func(...interface{}) *Error {
+	return s.ctors.factory(arg, false, false, 0)
+}
+
+func (s *Scope) FactoryStack(depth int, arg ...string) func(...interface{}) *Error {
+	return s.ctors.factory(arg, false, true, depth)
+}
+
+func (s *Scope) SystemFactory(arg ...string) func(...interface{}) *Error {
+	return s.ctors.factory(arg, true, false, 0)
+}
+
+func (s *Scope) SystemFactoryStack(depth int, arg ...string) func(...interface{}) *Error {
+	return s.ctors.factory(arg, true, true, depth)
+}
+
+func (s *Scope) Wrap(code string, err error) *Error {
+	return s.ctors.wrap(code, err)
+}
+
+func (s *Scope) WrapStack(code string, err error, depth int) *Error {
+	return s.ctors.wrapStack(code, err, depth)
+}
+
+func (s *Scope) WrapFactory(code string) func(err error) *Error {
+	return func(err error) *Error {
+		return s.Wrap(code, err)
+	}
+}
+
+func (s *Scope) WrapFactoryStack(depth int, code string) func(err error) *Error {
+	return func(err error) *Error {
+		return s.WrapStack(code, err, depth)
+	}
+}
+
+func (b *Error) Scope() *Scope {
+	if b == nil {
+		return nil
+	}
+	return b.scope
+}
+
+func (b *Error) cfg() *Config {
+	if b == nil {
+		return cfg()
+	}
+	if b.scope != nil {
+		return b.scope.config.Load()
+	}
+	return cfg()
+}
+
+func (b *Error) registry() *Registry {
+	if b == nil {
+		return DefaultRegistry
+	}
+	if b.scope != nil {
+		return b.scope.registry
+	}
+	return DefaultRegistry
+}
+
+// registryOf returns the registry governing err: its scope's, or DefaultRegistry.
+func registryOf(err error) *Registry {
+	e := errorOf(err)
+	if e != nil {
+		return e.registry()
+	}
+	return DefaultRegistry
+}
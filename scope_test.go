@@ -0,0 +1,66 @@
+package baseError
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+)
+
+func TestScope(t *testing.T) {
+	prev := CurrentConfig()
+	defer Configure(prev)
+
+	var global, scoped []error
+	AddHook(func(err error) { global = append(global, err) })
+
+	s := NewScope("payments-sdk")
+	c := s.CurrentConfig()
+	c.SanitizedCode = "SDK_ERROR"
+	c.MaxEnvelopeSize = 0
+	s.Configure(c)
+	s.AddHook(func(err error) { scoped = append(scoped, err) })
+	s.Registry().SetLogLevel("SDK_TIMEOUT", LevelInfo)
+	s.Registry().SetRetryable("SDK_TIMEOUT", true)
+
+	e := s.System("SDK_TIMEOUT", "upstream timed out")
+	if e.Scope() != s {
+		t.Fatal("scope not recorded")
+	}
+	if LogLevel(e) != LevelInfo || !IsRetryable(e) {
+		t.Errorf("scope registry ignored: %v %v", LogLevel(e), IsRetryable(e))
+	}
+	if LogLevel(System("SDK_TIMEOUT", "x")) != LevelError {
+		t.Error("scope registry leaked into DefaultRegistry")
+	}
+	if got := Sanitize(e); got.Code != "SDK_ERROR" {
+		t.Errorf("sanitize = %v", got)
+	}
+	if got := Sanitize(errors.New("raw")); got.Code != prev.SanitizedCode {
+		t.Errorf("global sanitize = %v", got)
+	}
+
+	Report(e)
+	Report(New("APP", "app"))
+	if len(scoped) != 1 || len(global) != 1 || !Equal(scoped[0], e) {
+		t.Errorf("hooks: scoped=%v global=%v", scoped, global)
+	}
+
+	w := s.WrapFactory("SDK_IO")(errors.New("eof"))
+	if w.Scope() != s || w.Code != "SDK_IO" {
+		t.Errorf("got %v", w)
+	}
+	if _, err := json.Marshal(w); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestErrorMaker(t *testing.T) {
+	for _, m := range []ErrorMaker{Default, NewScope("maker")} {
+		if e := m.NewStack("MAKER", "x", 4); e.Code != "MAKER" || e.Stack() == nil {
+			t.Errorf("got %+v", e)
+		}
+		if m.Wrap("MAKER", nil) != nil {
+			t.Error("wrapped nil")
+		}
+	}
+}
@@ -0,0 +1,34 @@
+package baseError
+
+type codeError string
+
+// CodeError returns a comparable sentinel that matches, via errors.Is, any *Error with
+// code:
+//
+//	if errors.Is(err, baseError.CodeError("AUTH_EXPIRED")) { ... }
+func CodeError(code string) error {
+	return codeError(code)
+}
+
+func (c codeError) Error() string {
+	return "[" + string(c) + "]"
+}
+
+// Is matches CodeError sentinels and other *Error values with the same code, whatever
+// their message, so factories double as sentinels:
+//
+//	errors.Is(err, baseError.New("USER_NOT_FOUND", ""))
+//
+// Legacy errors from the pkg/errors shims only match themselves.
+func (b *Error) Is(target error) bool {
+	if b == nil {
+		return false
+	}
+	switch t := target.(type) {
+	case codeError:
+		return string(t) == b.Code
+	case *Error:
+		return t != nil && t.Code == b.Code && b.Code != "" && b.Code != CodeLegacy
+	}
+	return false
+}
@@ -0,0 +1,39 @@
+package baseError
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+)
+
+func TestCodeError(t *testing.T) {
+	err := fmt.Errorf("refresh: %w", New("AUTH_EXPIRED", "token expired"))
+	if !errors.Is(err, CodeError("AUTH_EXPIRED")) {
+		t.Error("expected match")
+	}
+	if errors.Is(err, CodeError("AUTH_INVALID")) {
+		t.Error("unexpected match")
+	}
+	if CodeError("AUTH_EXPIRED") != CodeError("AUTH_EXPIRED") {
+		t.Error("sentinels should be comparable")
+	}
+}
+
+func TestIsByCode(t *testing.T) {
+	notFound := Factory("USER_NOT_FOUND", "user {} not found")
+	err := Wrap("LOAD_FAILED", fmt.Errorf("load: %w", notFound(42)))
+	if !errors.Is(err, notFound()) || !errors.Is(err, New("LOAD_FAILED", "")) {
+		t.Error("expected match by code")
+	}
+	if errors.Is(err, New("USER_INVALID", "user 42 not found")) {
+		t.Error("matched on message")
+	}
+	var nilErr *Error
+	if errors.Is(err, nilErr) {
+		t.Error("matched a nil *Error")
+	}
+	legacy := &Error{Code: CodeLegacy, Msg: "a"}
+	if errors.Is(legacy, &Error{Code: CodeLegacy, Msg: "b"}) || !errors.Is(legacy, legacy) {
+		t.Error("legacy errors should only match themselves")
+	}
+}
@@ -0,0 +1,16 @@
+module github.com/go-tron/base-error/sentryx
+
+go 1.26.0
+
+require (
+	github.com/getsentry/sentry-go v0.49.0
+	github.com/go-tron/base-error v0.0.0-00010101000000-000000000000
+)
+
+require (
+	github.com/pkg/errors v0.9.1 // indirect
+	golang.org/x/sys v0.48.0 // indirect
+	golang.org/x/text v0.42.0 // indirect
+)
+
+replace github.com/go-tron/base-error => ../
@@ -0,0 +1,62 @@
+// Package sentryx converts baseError values to Sentry events.
+package sentryx
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/getsentry/sentry-go"
+	baseError "github.com/go-tron/base-error"
+)
+
+// ToEvent converts err to a Sentry event. Errors carrying a *Error are fingerprinted by
+// their code, so Sentry groups them per code, have level error for System errors and
+// warning otherwise, and come with the captured stack as native frames and the fields
+// as the "fields" context. Foreign errors use Sentry's own stack extraction.
+func ToEvent(err error) *sentry.Event {
+	if err == nil {
+		return nil
+	}
+	event := sentry.NewEvent()
+	event.Message = err.Error()
+	var e *baseError.Error
+	if !errors.As(err, &e) || e == nil {
+		event.Level = sentry.LevelError
+		event.Exception = []sentry.Exception{{
+			Type:       fmt.Sprintf("%T", err),
+			Value:      err.Error(),
+			Stacktrace: sentry.ExtractStacktrace(err),
+		}}
+		return event
+	}
+
+	event.Level = sentry.LevelWarning
+	if e.System {
+		event.Level = sentry.LevelError
+	}
+	event.Fingerprint = []string{e.Code}
+	event.Tags["code"] = e.Code
+	event.Tags["system"] = fmt.Sprint(e.System)
+	if fields := e.Fields(); len(fields) > 0 {
+		event.Contexts["fields"] = fields
+	}
+	if e.Chain != "" {
+		event.Tags["chain"] = e.Chain
+	}
+	event.Exception = []sentry.Exception{{Type: e.Code, Value: e.Msg, Stacktrace: Stacktrace(e)}}
+	return event
+}
+
+// Stacktrace returns the stack of the first stack-carrying error in err's chain in
+// Sentry's order, outermost call first, or nil.
+func Stacktrace(err error) *sentry.Stacktrace {
+	frames := baseError.StackFrames(err)
+	if len(frames) == 0 {
+		return nil
+	}
+	out := make([]sentry.Frame, len(frames))
+	for i, f := range frames {
+		out[len(frames)-1-i] = sentry.NewFrame(f)
+	}
+	return &sentry.Stacktrace{Frames: out}
+}
@@ -0,0 +1,37 @@
+package sentryx
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/getsentry/sentry-go"
+	baseError "github.com/go-tron/base-error"
+)
+
+func TestToEvent(t *testing.T) {
+	e := baseError.SystemStack("SENTRYX_LEDGER", "ledger down", 8).WithField("account", 7)
+	event := ToEvent(fmt.Errorf("charge: %w", e))
+
+	if event.Level != sentry.LevelError || len(event.Fingerprint) != 1 || event.Fingerprint[0] != "SENTRYX_LEDGER" {
+		t.Errorf("event = %+v", event)
+	}
+	if event.Contexts["fields"]["account"] != 7 || event.Tags["system"] != "true" {
+		t.Errorf("contexts = %v tags = %v", event.Contexts, event.Tags)
+	}
+	ex := event.Exception[0]
+	frames := ex.Stacktrace.Frames
+	if ex.Type != "SENTRYX_LEDGER" || len(frames) == 0 || frames[len(frames)-1].Function != sentry.NewFrame(e.Stack().Frames()[0]).Function {
+		t.Errorf("exception = %+v", ex)
+	}
+
+	if ToEvent(baseError.New("SENTRYX_BAD", "")).Level != sentry.LevelWarning {
+		t.Error("business error not a warning")
+	}
+	if event := ToEvent(errors.New("raw")); event.Exception[0].Type != "*errors.errorString" || event.Fingerprint != nil {
+		t.Errorf("foreign event = %+v", event)
+	}
+	if ToEvent(nil) != nil {
+		t.Error("nil converted")
+	}
+}
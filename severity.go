@@ -0,0 +1,52 @@
+package baseError
+
+type Severity int8
+
+const (
+	SeverityInfo Severity = iota + 1
+	SeverityWarning
+	SeverityError
+	SeverityCritical
+)
+
+func (s Severity) String() string {
+	switch s {
+	case SeverityInfo:
+		return "info"
+	case SeverityWarning:
+		return "warning"
+	case SeverityError:
+		return "error"
+	case SeverityCritical:
+		return "critical"
+	}
+	return ""
+}
+
+func (b *Error) WithSeverity(severity Severity) *Error {
+	b.severity = severity
+	return b
+}
+
+// SeverityOf returns the severity set on the first *Error in the chain, else the one
+// registered for its code, else SeverityError for System and foreign errors and
+// SeverityWarning otherwise.
+func SeverityOf(err error) Severity {
+	if isNil(err) {
+		return 0
+	}
+	e := errorOf(err)
+	if e == nil {
+		return SeverityError
+	}
+	if e.severity != 0 {
+		return e.severity
+	}
+	if info, ok := e.registry().Lookup(e.Code); ok && info.Severity != 0 {
+		return info.Severity
+	}
+	if e.System {
+		return SeverityError
+	}
+	return SeverityWarning
+}
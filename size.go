@@ -0,0 +1,58 @@
+package baseError
+
+import (
+	"encoding/json"
+)
+
+// Size returns the size in bytes of err's JSON envelope.
+func Size(err error) int {
+	if isNil(err) {
+		return 0
+	}
+	e := errorOf(err)
+	if e == nil {
+		e = &Error{Code: CodeOf(err), Msg: err.Error()}
+	}
+	buf, _ := json.Marshal(e.envelope())
+	return len(buf)
+}
+
+// MarshalLimited marshals err's envelope in at most limit bytes. Oversized envelopes lose,
+// in order, their cause tree and fields, their details and as much of the message as needed, and are
+// marked "truncated". A limit of zero or less means unlimited, and a nil err marshals as
+// null.
+func MarshalLimited(err *Error, limit int) ([]byte, error) {
+	return err.marshal(limit)
+}
+
+func (b *Error) marshal(limit int) ([]byte, error) {
+	if b == nil {
+		return []byte("null"), nil
+	}
+	out := b.envelope()
+	buf, err := json.Marshal(out)
+	if err != nil || limit <= 0 || len(buf) <= limit {
+		return buf, err
+	}
+
+	out.Truncated = true
+	out.Frame, out.Causes, out.Fields = "", nil, nil
+	if buf, err = json.Marshal(out); err != nil || len(buf) <= limit {
+		return buf, err
+	}
+	out.Details = nil
+	if buf, err = json.Marshal(out); err != nil || len(buf) <= limit {
+		return buf, err
+	}
+	for len(buf) > limit && out.Msg != "" {
+		short := Truncate(out.Msg, len(out.Msg)-(len(buf)-limit))
+		if short == out.Msg {
+			short = Truncate(out.Msg, len(out.Msg)-1)
+		}
+		out.Msg = short
+		if buf, err = json.Marshal(out); err != nil {
+			return nil, err
+		}
+	}
+	return buf, nil
+}
@@ -0,0 +1,47 @@
+package baseError
+
+import (
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestSize(t *testing.T) {
+	if n := Size(New("A", "b")); n != len(`{"code":"A","msg":"b"}`) {
+		t.Errorf("size = %d", n)
+	}
+	if n := Size(errors.New("b")); n != len(`{"code":"UNKNOWN","msg":"b"}`) {
+		t.Errorf("size = %d", n)
+	}
+}
+
+func TestMarshalLimited(t *testing.T) {
+	err := New("BATCH_INVALID", strings.Repeat("x", 200)).WithSafeDetails(strings.Repeat("d", 100))
+
+	if buf, _ := MarshalLimited(err, 0); len(buf) != Size(err) {
+		t.Errorf("unlimited marshal changed the envelope: %s", buf)
+	}
+	buf, _ := MarshalLimited(err, 120)
+	if len(buf) > 120 {
+		t.Errorf("len = %d", len(buf))
+	}
+	var out struct {
+		Code      string
+		Details   []string
+		Truncated bool
+	}
+	json.Unmarshal(buf, &out)
+	if out.Code != "BATCH_INVALID" || out.Details != nil || !out.Truncated {
+		t.Errorf("got %s", buf)
+	}
+
+	prev := CurrentConfig()
+	defer Configure(prev)
+	c := CurrentConfig()
+	c.MaxEnvelopeSize = 64
+	Configure(c)
+	if buf, _ := json.Marshal(err); len(buf) > 64 {
+		t.Errorf("MaxEnvelopeSize not applied: %s", buf)
+	}
+}
@@ -0,0 +1,144 @@
+//go:build go1.21
+
+// Package slogx makes log/slog output structured for baseError values.
+package slogx
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"sort"
+
+	baseError "github.com/go-tron/base-error"
+)
+
+type handler struct {
+	next slog.Handler
+}
+
+// NewHandler wraps next so that any attribute holding an error with a *Error in its
+// chain is logged as a group with the code, kind ("system" or "business"), message,
+// fields and stack, however the call site logged it.
+func NewHandler(next slog.Handler) slog.Handler {
+	if h, ok := next.(*handler); ok {
+		return h
+	}
+	return &handler{next: next}
+}
+
+func (h *handler) Enabled(ctx context.Context, level slog.Level) bool {
+	return h.next.Enabled(ctx, level)
+}
+
+func (h *handler) Handle(ctx context.Context, r slog.Record) error {
+	out := slog.NewRecord(r.Time, r.Level, r.Message, r.PC)
+	r.Attrs(func(a slog.Attr) bool {
+		out.AddAttrs(expand(a))
+		return true
+	})
+	return h.next.Handle(ctx, out)
+}
+
+func (h *handler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	expanded := make([]slog.Attr, len(attrs))
+	for i, a := range attrs {
+		expanded[i] = expand(a)
+	}
+	return &handler{next: h.next.WithAttrs(expanded)}
+}
+
+func (h *handler) WithGroup(name string) slog.Handler {
+	return &handler{next: h.next.WithGroup(name)}
+}
+
+func expand(a slog.Attr) slog.Attr {
+	switch a.Value.Kind() {
+	case slog.KindGroup:
+		group := a.Value.Group()
+		attrs := make([]slog.Attr, len(group))
+		for i, g := range group {
+			attrs[i] = expand(g)
+		}
+		return slog.Attr{Key: a.Key, Value: slog.GroupValue(attrs...)}
+	case slog.KindAny, slog.KindLogValuer:
+		if err, ok := a.Value.Any().(error); ok {
+			if g, ok := Group(a.Key, err); ok {
+				return g
+			}
+		}
+	}
+	return a
+}
+
+// Group returns err as a slog group named key, and false if err carries no *Error.
+func Group(key string, err error) (slog.Attr, bool) {
+	var e *baseError.Error
+	if !errors.As(err, &e) || e == nil {
+		return slog.Attr{}, false
+	}
+	kind := "business"
+	if e.System {
+		kind = "system"
+	}
+	attrs := []slog.Attr{
+		slog.String("code", e.Code),
+		slog.String("kind", kind),
+		slog.String("msg", e.Msg),
+	}
+	if fields := e.Fields(); len(fields) > 0 {
+		keys := make([]string, 0, len(fields))
+		for k := range fields {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		group := make([]slog.Attr, len(keys))
+		for i, k := range keys {
+			group[i] = slog.Any(k, fields[k])
+		}
+		attrs = append(attrs, slog.Attr{Key: "fields", Value: slog.GroupValue(group...)})
+	}
+	if frames := baseError.StackFrames(err); len(frames) > 0 {
+		stack := make([]string, len(frames))
+		for i, f := range frames {
+			stack[i] = fmt.Sprintf("%s %s:%d", f.Function, f.File, f.Line)
+		}
+		attrs = append(attrs, slog.Any("stack", stack))
+	}
+	return slog.Attr{Key: key, Value: slog.GroupValue(attrs...)}, true
+}
+
+// Level returns the slog level of baseError.LogLevel(err).
+func Level(err error) slog.Level {
+	return slogLevel(baseError.LogLevel(err))
+}
+
+func slogLevel(l baseError.Level) slog.Level {
+	switch l {
+	case baseError.LevelDebug:
+		return slog.LevelDebug
+	case baseError.LevelInfo:
+		return slog.LevelInfo
+	case baseError.LevelWarn:
+		return slog.LevelWarn
+	}
+	return slog.LevelError
+}
+
+// Log logs err as attribute "err" at Level(err), after args. Nothing is logged when
+// baseError.ShouldLog rejects err for the lowest level logger is enabled at.
+func Log(ctx context.Context, logger *slog.Logger, msg string, err error, args ...interface{}) {
+	if !baseError.ShouldLog(err, minLevel(ctx, logger)) {
+		return
+	}
+	logger.Log(ctx, Level(err), msg, append(args, "err", err)...)
+}
+
+func minLevel(ctx context.Context, logger *slog.Logger) baseError.Level {
+	for _, l := range []baseError.Level{baseError.LevelDebug, baseError.LevelInfo, baseError.LevelWarn} {
+		if logger.Enabled(ctx, slogLevel(l)) {
+			return l
+		}
+	}
+	return baseError.LevelError
+}
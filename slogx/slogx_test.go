@@ -0,0 +1,81 @@
+//go:build go1.21
+
+package slogx
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"testing"
+
+	baseError "github.com/go-tron/base-error"
+)
+
+func TestHandler(t *testing.T) {
+	var buf bytes.Buffer
+	logger := slog.New(NewHandler(slog.NewJSONHandler(&buf, nil)))
+
+	err := fmt.Errorf("charge: %w", baseError.SystemStack("SLOGX_LEDGER", "ledger down", 4).WithField("account", 7))
+	logger.With("req", "r1").Error("failed", "err", err, slog.Group("ctx", "cause", baseError.New("SLOGX_BAD", "bad")), "plain", errors.New("raw"))
+
+	var line map[string]interface{}
+	if err := json.Unmarshal(buf.Bytes(), &line); err != nil {
+		t.Fatal(err)
+	}
+	e := line["err"].(map[string]interface{})
+	if e["code"] != "SLOGX_LEDGER" || e["kind"] != "system" || e["msg"] != "ledger down" {
+		t.Errorf("err = %v", e)
+	}
+	if f := e["fields"].(map[string]interface{}); f["account"] != float64(7) {
+		t.Errorf("fields = %v", f)
+	}
+	if st, _ := e["stack"].([]interface{}); len(st) == 0 {
+		t.Error("stack missing")
+	}
+	if c := line["ctx"].(map[string]interface{})["cause"].(map[string]interface{}); c["kind"] != "business" {
+		t.Errorf("nested = %v", c)
+	}
+	if line["plain"] != "raw" || line["req"] != "r1" {
+		t.Errorf("line = %v", line)
+	}
+}
+
+func TestGroup(t *testing.T) {
+	if _, ok := Group("err", errors.New("raw")); ok {
+		t.Error("foreign error expanded")
+	}
+	var nilErr *baseError.Error
+	if _, ok := Group("err", nilErr); ok {
+		t.Error("nil *Error expanded")
+	}
+}
+
+func TestLog(t *testing.T) {
+	var buf bytes.Buffer
+	logger := slog.New(NewHandler(slog.NewJSONHandler(&buf, &slog.HandlerOptions{Level: slog.LevelInfo})))
+	baseError.DefaultRegistry.SetLogLevel("SLOGX_QUIET", baseError.LevelDebug)
+	baseError.DefaultRegistry.SetLogLevel("SLOGX_NOTE", baseError.LevelInfo)
+
+	Log(context.Background(), logger, "quiet", baseError.System("SLOGX_QUIET", ""))
+	baseError.Suppress("SLOGX_MUTED", 0)
+	defer baseError.Unsuppress("SLOGX_MUTED")
+	Log(context.Background(), logger, "muted", baseError.System("SLOGX_MUTED", ""))
+	if buf.Len() != 0 {
+		t.Fatalf("logged %s", buf.String())
+	}
+
+	Log(context.Background(), logger, "note", baseError.System("SLOGX_NOTE", ""), "req", "r1")
+	var line map[string]interface{}
+	if err := json.Unmarshal(buf.Bytes(), &line); err != nil {
+		t.Fatal(err)
+	}
+	if line["level"] != "INFO" || line["req"] != "r1" || line["err"].(map[string]interface{})["code"] != "SLOGX_NOTE" {
+		t.Errorf("line = %v", line)
+	}
+	if Level(baseError.New("SLOGX_PLAIN", "")) != slog.LevelWarn {
+		t.Error("business errors default to warn")
+	}
+}
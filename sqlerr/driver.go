@@ -0,0 +1,274 @@
+package sqlerr
+
+import (
+	"context"
+	"crypto/sha1"
+	"database/sql/driver"
+	"encoding/hex"
+	"errors"
+	"io"
+	"regexp"
+	"strings"
+	"time"
+
+	baseError "github.com/go-tron/base-error"
+)
+
+const FieldQuery = "query_fingerprint"
+
+// Wrap returns a driver whose errors are translated with Translate and annotated with the
+// query fingerprint and duration, for use with sql.Register:
+//
+//	sql.Register("postgres-coded", sqlerr.Wrap(&pq.Driver{}))
+//
+// driver.ErrBadConn, driver.ErrSkip and driver.ErrRemoveArgument pass through untouched
+// because database/sql depends on them.
+func Wrap(d driver.Driver) driver.Driver {
+	return &wrappedDriver{d}
+}
+
+// WrapConnector is Wrap for sql.OpenDB.
+func WrapConnector(c driver.Connector) driver.Connector {
+	return &wrappedConnector{c}
+}
+
+var (
+	literals   = regexp.MustCompile(`'(?:[^']|'')*'|\b\d+(?:\.\d+)?\b`)
+	whitespace = regexp.MustCompile(`\s+`)
+)
+
+// Fingerprint identifies query independently of its literal values.
+func Fingerprint(query string) string {
+	q := literals.ReplaceAllString(query, "?")
+	q = strings.ToLower(strings.TrimSpace(whitespace.ReplaceAllString(q, " ")))
+	sum := sha1.Sum([]byte(q))
+	return hex.EncodeToString(sum[:8])
+}
+
+func translate(err error, query string, start time.Time) error {
+	if err == nil || err == io.EOF || errors.Is(err, driver.ErrBadConn) || errors.Is(err, driver.ErrSkip) || errors.Is(err, driver.ErrRemoveArgument) {
+		return err
+	}
+	e := Translate(err).WithField(baseError.FieldDuration, baseError.Since(start))
+	if query != "" {
+		e.WithField(FieldQuery, Fingerprint(query))
+	}
+	return e
+}
+
+type wrappedDriver struct {
+	driver.Driver
+}
+
+func (d *wrappedDriver) Open(name string) (driver.Conn, error) {
+	start := baseError.Now()
+	c, err := d.Driver.Open(name)
+	if err != nil {
+		return nil, translate(err, "", start)
+	}
+	return &conn{c}, nil
+}
+
+type wrappedConnector struct {
+	driver.Connector
+}
+
+func (c *wrappedConnector) Connect(ctx context.Context) (driver.Conn, error) {
+	start := baseError.Now()
+	cn, err := c.Connector.Connect(ctx)
+	if err != nil {
+		return nil, translate(err, "", start)
+	}
+	return &conn{cn}, nil
+}
+
+func (c *wrappedConnector) Driver() driver.Driver {
+	return &wrappedDriver{c.Connector.Driver()}
+}
+
+type conn struct {
+	driver.Conn
+}
+
+func (c *conn) Prepare(query string) (driver.Stmt, error) {
+	return c.PrepareContext(context.Background(), query)
+}
+
+func (c *conn) PrepareContext(ctx context.Context, query string) (driver.Stmt, error) {
+	start := baseError.Now()
+	var s driver.Stmt
+	var err error
+	if p, ok := c.Conn.(driver.ConnPrepareContext); ok {
+		s, err = p.PrepareContext(ctx, query)
+	} else {
+		s, err = c.Conn.Prepare(query)
+	}
+	if err != nil {
+		return nil, translate(err, query, start)
+	}
+	return &stmt{s, query}, nil
+}
+
+func (c *conn) Begin() (driver.Tx, error) {
+	return c.BeginTx(context.Background(), driver.TxOptions{})
+}
+
+func (c *conn) BeginTx(ctx context.Context, opts driver.TxOptions) (driver.Tx, error) {
+	start := baseError.Now()
+	var t driver.Tx
+	var err error
+	if b, ok := c.Conn.(driver.ConnBeginTx); ok {
+		t, err = b.BeginTx(ctx, opts)
+	} else {
+		t, err = c.Conn.Begin()
+	}
+	if err != nil {
+		return nil, translate(err, "", start)
+	}
+	return &tx{t}, nil
+}
+
+func (c *conn) ExecContext(ctx context.Context, query string, args []driver.NamedValue) (driver.Result, error) {
+	e, ok := c.Conn.(driver.ExecerContext)
+	if !ok {
+		return nil, driver.ErrSkip
+	}
+	start := baseError.Now()
+	r, err := e.ExecContext(ctx, query, args)
+	return r, translate(err, query, start)
+}
+
+func (c *conn) QueryContext(ctx context.Context, query string, args []driver.NamedValue) (driver.Rows, error) {
+	q, ok := c.Conn.(driver.QueryerContext)
+	if !ok {
+		return nil, driver.ErrSkip
+	}
+	start := baseError.Now()
+	r, err := q.QueryContext(ctx, query, args)
+	if err != nil {
+		return nil, translate(err, query, start)
+	}
+	return &rows{r, query, start}, nil
+}
+
+func (c *conn) Ping(ctx context.Context) error {
+	if p, ok := c.Conn.(driver.Pinger); ok {
+		start := baseError.Now()
+		return translate(p.Ping(ctx), "", start)
+	}
+	return nil
+}
+
+func (c *conn) ResetSession(ctx context.Context) error {
+	if r, ok := c.Conn.(driver.SessionResetter); ok {
+		return r.ResetSession(ctx)
+	}
+	return nil
+}
+
+func (c *conn) IsValid() bool {
+	if v, ok := c.Conn.(driver.Validator); ok {
+		return v.IsValid()
+	}
+	return true
+}
+
+func (c *conn) CheckNamedValue(nv *driver.NamedValue) error {
+	if n, ok := c.Conn.(driver.NamedValueChecker); ok {
+		return n.CheckNamedValue(nv)
+	}
+	return driver.ErrSkip
+}
+
+type stmt struct {
+	driver.Stmt
+	query string
+}
+
+func (s *stmt) Exec(args []driver.Value) (driver.Result, error) {
+	start := baseError.Now()
+	r, err := s.Stmt.Exec(args)
+	return r, translate(err, s.query, start)
+}
+
+func (s *stmt) Query(args []driver.Value) (driver.Rows, error) {
+	start := baseError.Now()
+	r, err := s.Stmt.Query(args)
+	if err != nil {
+		return nil, translate(err, s.query, start)
+	}
+	return &rows{r, s.query, start}, nil
+}
+
+func (s *stmt) ExecContext(ctx context.Context, args []driver.NamedValue) (driver.Result, error) {
+	e, ok := s.Stmt.(driver.StmtExecContext)
+	if !ok {
+		values, err := namedValues(args)
+		if err != nil {
+			return nil, err
+		}
+		return s.Exec(values)
+	}
+	start := baseError.Now()
+	r, err := e.ExecContext(ctx, args)
+	return r, translate(err, s.query, start)
+}
+
+func (s *stmt) QueryContext(ctx context.Context, args []driver.NamedValue) (driver.Rows, error) {
+	q, ok := s.Stmt.(driver.StmtQueryContext)
+	if !ok {
+		values, err := namedValues(args)
+		if err != nil {
+			return nil, err
+		}
+		return s.Query(values)
+	}
+	start := baseError.Now()
+	r, err := q.QueryContext(ctx, args)
+	if err != nil {
+		return nil, translate(err, s.query, start)
+	}
+	return &rows{r, s.query, start}, nil
+}
+
+func (s *stmt) CheckNamedValue(nv *driver.NamedValue) error {
+	if n, ok := s.Stmt.(driver.NamedValueChecker); ok {
+		return n.CheckNamedValue(nv)
+	}
+	return driver.ErrSkip
+}
+
+func namedValues(args []driver.NamedValue) ([]driver.Value, error) {
+	values := make([]driver.Value, len(args))
+	for i, a := range args {
+		if a.Name != "" {
+			return nil, errors.New("sqlerr: driver does not support named parameters")
+		}
+		values[i] = a.Value
+	}
+	return values, nil
+}
+
+type rows struct {
+	driver.Rows
+	query string
+	start time.Time
+}
+
+func (r *rows) Next(dest []driver.Value) error {
+	return translate(r.Rows.Next(dest), r.query, r.start)
+}
+
+type tx struct {
+	driver.Tx
+}
+
+func (t *tx) Commit() error {
+	start := baseError.Now()
+	return translate(t.Tx.Commit(), "", start)
+}
+
+func (t *tx) Rollback() error {
+	start := baseError.Now()
+	return translate(t.Tx.Rollback(), "", start)
+}
@@ -0,0 +1,76 @@
+package sqlerr
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"io"
+	"testing"
+
+	baseError "github.com/go-tron/base-error"
+)
+
+type fakeDriver struct{}
+
+func (fakeDriver) Open(string) (driver.Conn, error) { return fakeConn{}, nil }
+
+type fakeConn struct{}
+
+func (fakeConn) Prepare(string) (driver.Stmt, error) { return nil, errors.New("unsupported") }
+func (fakeConn) Close() error                        { return nil }
+func (fakeConn) Begin() (driver.Tx, error)           { return fakeTx{}, nil }
+
+var rollbackErr error
+
+type fakeTx struct{}
+
+func (fakeTx) Commit() error   { return nil }
+func (fakeTx) Rollback() error { return rollbackErr }
+
+func (fakeConn) ExecContext(ctx context.Context, query string, args []driver.NamedValue) (driver.Result, error) {
+	return nil, stateError("23505")
+}
+
+func (fakeConn) QueryContext(ctx context.Context, query string, args []driver.NamedValue) (driver.Rows, error) {
+	return &fakeRows{}, nil
+}
+
+type fakeRows struct{ n int }
+
+func (*fakeRows) Columns() []string { return []string{"id"} }
+func (*fakeRows) Close() error      { return nil }
+func (r *fakeRows) Next(dest []driver.Value) error {
+	r.n++
+	if r.n > 1 {
+		return io.EOF
+	}
+	dest[0] = int64(1)
+	return nil
+}
+
+func init() {
+	sql.Register("sqlerr-fake", Wrap(fakeDriver{}))
+}
+
+func TestDriver(t *testing.T) {
+	db, err := sql.Open("sqlerr-fake", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+
+	_, err = db.Exec("INSERT INTO users (id, name) VALUES (7, 'ann')")
+	var e *baseError.Error
+	if !errors.As(err, &e) || e.Code != CodeDuplicate {
+		t.Fatalf("err = %v", err)
+	}
+	if e.Fields()[FieldQuery] != Fingerprint("insert into users (id, name) values (8, 'bob')") || e.Fields()[baseError.FieldDuration] == nil {
+		t.Errorf("fields = %v", e.Fields())
+	}
+
+	var id int
+	if err := db.QueryRow("SELECT id FROM users").Scan(&id); err != nil || id != 1 {
+		t.Errorf("id=%d err=%v", id, err)
+	}
+}
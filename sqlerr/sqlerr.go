@@ -0,0 +1,112 @@
+// Package sqlerr maps database/sql and driver errors to baseError codes.
+package sqlerr
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"strings"
+
+	baseError "github.com/go-tron/base-error"
+)
+
+const (
+	CodeNotFound    = "DB_NOT_FOUND"
+	CodeDuplicate   = "DB_DUPLICATE"
+	CodeForeignKey  = "DB_FOREIGN_KEY"
+	CodeConstraint  = "DB_CONSTRAINT"
+	CodeConflict    = "DB_CONFLICT"
+	CodeTimeout     = "DB_TIMEOUT"
+	CodeConnection  = "DB_CONNECTION"
+	CodeUnavailable = "DB_UNAVAILABLE"
+	CodeTxDone      = "DB_TX_DONE"
+	CodeDB          = "DB_ERROR"
+)
+
+func init() {
+	for _, code := range []string{CodeConflict, CodeTimeout, CodeConnection, CodeUnavailable} {
+		baseError.RegisterRetryable(code, true)
+	}
+}
+
+// Code returns the code for a database error. Drivers exposing SQLSTATE through a
+// SQLState() method (pgx, lib/pq and others) are mapped by state class; anything else
+// unrecognized is CodeDB.
+func Code(err error) string {
+	switch {
+	case err == nil:
+		return ""
+	case errors.Is(err, sql.ErrNoRows):
+		return CodeNotFound
+	case errors.Is(err, sql.ErrTxDone):
+		return CodeTxDone
+	case errors.Is(err, driver.ErrBadConn), errors.Is(err, sql.ErrConnDone):
+		return CodeConnection
+	case errors.Is(err, context.DeadlineExceeded):
+		return CodeTimeout
+	}
+	var s interface{ SQLState() string }
+	if errors.As(err, &s) {
+		if code := stateCode(s.SQLState()); code != "" {
+			return code
+		}
+	}
+	return CodeDB
+}
+
+// Classify is Code for use with baseError.AddClassifier: it returns "" for errors that
+// don't come from database/sql or a driver, instead of CodeDB.
+func Classify(err error) string {
+	switch {
+	case err == nil:
+		return ""
+	case errors.Is(err, sql.ErrNoRows), errors.Is(err, sql.ErrTxDone),
+		errors.Is(err, driver.ErrBadConn), errors.Is(err, sql.ErrConnDone):
+		return Code(err)
+	}
+	var s interface{ SQLState() string }
+	if errors.As(err, &s) {
+		return Code(err)
+	}
+	return ""
+}
+
+func stateCode(state string) string {
+	switch state {
+	case "23505":
+		return CodeDuplicate
+	case "23503":
+		return CodeForeignKey
+	case "40001", "40P01":
+		return CodeConflict
+	case "57014":
+		return CodeTimeout
+	}
+	switch {
+	case strings.HasPrefix(state, "23"):
+		return CodeConstraint
+	case strings.HasPrefix(state, "08"):
+		return CodeConnection
+	case strings.HasPrefix(state, "53"), strings.HasPrefix(state, "57P"):
+		return CodeUnavailable
+	}
+	return ""
+}
+
+// Translate wraps err with its Code. sql.ErrNoRows becomes a business error; everything
+// else is a System error. *Error values are returned unchanged.
+func Translate(err error) *baseError.Error {
+	if err == nil {
+		return nil
+	}
+	var e *baseError.Error
+	if errors.As(err, &e) {
+		return e
+	}
+	code := Code(err)
+	if code == CodeNotFound {
+		return baseError.New(code, err.Error())
+	}
+	return baseError.Wrap(code, err)
+}
@@ -0,0 +1,42 @@
+package sqlerr
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"testing"
+
+	baseError "github.com/go-tron/base-error"
+)
+
+type stateError string
+
+func (s stateError) Error() string    { return "pq: state " + string(s) }
+func (s stateError) SQLState() string { return string(s) }
+
+func TestCode(t *testing.T) {
+	cases := map[error]string{
+		sql.ErrNoRows:                        CodeNotFound,
+		stateError("23505"):                  CodeDuplicate,
+		stateError("23514"):                  CodeConstraint,
+		stateError("40P01"):                  CodeConflict,
+		stateError("08006"):                  CodeConnection,
+		stateError("XX000"):                  CodeDB,
+		errors.New("boom"):                   CodeDB,
+		fmt.Errorf("get: %w", sql.ErrNoRows): CodeNotFound,
+	}
+	for err, want := range cases {
+		if got := Code(err); got != want {
+			t.Errorf("Code(%v) = %q, want %q", err, got, want)
+		}
+	}
+	if Classify(errors.New("boom")) != "" || Classify(stateError("23505")) != CodeDuplicate {
+		t.Error("Classify")
+	}
+	if e := Translate(sql.ErrNoRows); e.System {
+		t.Error("not found is a System error")
+	}
+	if e := Translate(stateError("40001")); !e.System || !baseError.IsRetryable(e) {
+		t.Errorf("got %v", e)
+	}
+}
@@ -0,0 +1,48 @@
+package sqlerr
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	baseError "github.com/go-tron/base-error"
+)
+
+const FieldRollback = "rollback_error"
+
+// WithTx runs fn in a transaction, committing if it returns nil and rolling back
+// otherwise. Failures come back translated; a failed rollback is attached to the error
+// under the rollback_error field rather than replacing it. A panic in fn is rolled back
+// and re-raised as a System *Error carrying the panic stack.
+func WithTx(ctx context.Context, db *sql.DB, fn func(tx *sql.Tx) error) error {
+	tx, err := db.BeginTx(ctx, nil)
+	if err != nil {
+		return Translate(err)
+	}
+	defer func() {
+		if r := recover(); r != nil {
+			e := baseError.FromPanic(r)
+			rollback(tx, e)
+			panic(e)
+		}
+	}()
+
+	if err := fn(tx); err != nil {
+		var e *baseError.Error
+		if !errors.As(err, &e) {
+			e = Translate(err)
+		}
+		rollback(tx, e)
+		return e
+	}
+	if err := tx.Commit(); err != nil {
+		return Translate(err)
+	}
+	return nil
+}
+
+func rollback(tx *sql.Tx, e *baseError.Error) {
+	if err := tx.Rollback(); err != nil && !errors.Is(err, sql.ErrTxDone) {
+		e.WithField(FieldRollback, Translate(err))
+	}
+}
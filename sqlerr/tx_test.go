@@ -0,0 +1,45 @@
+package sqlerr
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"testing"
+
+	baseError "github.com/go-tron/base-error"
+)
+
+func TestWithTx(t *testing.T) {
+	db, err := sql.Open("sqlerr-fake", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+	ctx := context.Background()
+
+	if err := WithTx(ctx, db, func(tx *sql.Tx) error { return nil }); err != nil {
+		t.Errorf("commit: %v", err)
+	}
+
+	rollbackErr = stateError("08006")
+	defer func() { rollbackErr = nil }()
+	err = WithTx(ctx, db, func(tx *sql.Tx) error {
+		_, err := tx.Exec("INSERT INTO users VALUES (1)")
+		return err
+	})
+	var e *baseError.Error
+	if !errors.As(err, &e) || e.Code != CodeDuplicate {
+		t.Fatalf("err = %v", err)
+	}
+	if rb, _ := e.Fields()[FieldRollback].(*baseError.Error); rb == nil || rb.Code != CodeConnection {
+		t.Errorf("rollback = %v", e.Fields()[FieldRollback])
+	}
+
+	defer func() {
+		e, _ := recover().(*baseError.Error)
+		if e == nil || e.Code != baseError.CodePanic || !e.System {
+			t.Errorf("recovered %v", e)
+		}
+	}()
+	WithTx(ctx, db, func(tx *sql.Tx) error { panic("boom") })
+}
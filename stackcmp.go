@@ -0,0 +1,72 @@
+package baseError
+
+import (
+	"runtime"
+
+	"github.com/pkg/errors"
+)
+
+// StackFrames returns the resolved frames of the first stack found in err's chain.
+func StackFrames(err error) []runtime.Frame {
+	for depth := 0; !isNil(err) && depth < maxCauseDepth; depth++ {
+		switch x := err.(type) {
+		case *Error:
+			if x.stack != nil && len(*x.stack) > 0 {
+				return x.stack.Frames()
+			}
+		case interface{ StackTrace() errors.StackTrace }:
+			if trace := x.StackTrace(); len(trace) > 0 {
+				st := make(stack, len(trace))
+				for i, f := range trace {
+					st[i] = uintptr(f)
+				}
+				return st.Frames()
+			}
+		}
+		next := causes(err)
+		if len(next) == 0 {
+			return nil
+		}
+		err = next[0]
+	}
+	return nil
+}
+
+// StackEqual reports whether the innermost depth frames of a and b were captured at the
+// same function, file and line. A depth of zero compares whole stacks.
+func StackEqual(a, b error, depth int) bool {
+	fa, fb := StackFrames(a), StackFrames(b)
+	if depth > 0 {
+		if len(fa) < depth || len(fb) < depth {
+			return false
+		}
+		fa, fb = fa[:depth], fb[:depth]
+	}
+	if len(fa) != len(fb) || len(fa) == 0 {
+		return false
+	}
+	for i := range fa {
+		if !sameFrame(fa[i], fb[i]) {
+			return false
+		}
+	}
+	return true
+}
+
+// CommonSuffix returns the outermost frames shared by the stacks of a and b, innermost
+// first, i.e. the call path both errors were created under.
+func CommonSuffix(a, b error) []runtime.Frame {
+	fa, fb := StackFrames(a), StackFrames(b)
+	n := 0
+	for n < len(fa) && n < len(fb) && sameFrame(fa[len(fa)-1-n], fb[len(fb)-1-n]) {
+		n++
+	}
+	if n == 0 {
+		return nil
+	}
+	return fa[len(fa)-n:]
+}
+
+func sameFrame(a, b runtime.Frame) bool {
+	return a.Function == b.Function && a.File == b.File && a.Line == b.Line
+}
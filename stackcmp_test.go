@@ -0,0 +1,34 @@
+package baseError
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/pkg/errors"
+)
+
+func stackAt() error {
+	return errors.New("here")
+}
+
+func TestStackEqual(t *testing.T) {
+	a := stackAt()
+	b := stackAt()
+	if !StackEqual(a, b, 1) {
+		t.Error("errors from the same function should have an equal top frame")
+	}
+	if StackEqual(a, b, 0) {
+		t.Error("full stacks differ in the calling line")
+	}
+	if StackEqual(a, errors.New("elsewhere"), 1) {
+		t.Error("different sites should not be equal")
+	}
+	if StackEqual(New("A", ""), New("A", ""), 1) {
+		t.Error("errors without stacks are never equal")
+	}
+
+	suffix := CommonSuffix(Wrap("W", a), b)
+	if len(suffix) == 0 || strings.Contains(suffix[0].Function, "stackAt") {
+		t.Errorf("suffix = %v", suffix)
+	}
+}
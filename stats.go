@@ -0,0 +1,122 @@
+package baseError
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"sync"
+	"text/tabwriter"
+	"time"
+)
+
+const statsWindow = 256
+
+// CodeStats is the digest of one code in Stats. P50 and P95 are inter-arrival times over
+// the last 256 occurrences.
+type CodeStats struct {
+	Code      string        `json:"code"`
+	Count     int           `json:"count"`
+	FirstSeen time.Time     `json:"first_seen"`
+	LastSeen  time.Time     `json:"last_seen"`
+	P50       time.Duration `json:"p50_interval"`
+	P95       time.Duration `json:"p95_interval"`
+}
+
+// Stats accumulates occurrence statistics per code. Feed it with AddHook(stats.Hook()) or
+// Add. The zero value is ready to use.
+type Stats struct {
+	mu    sync.Mutex
+	codes map[string]*codeStats
+}
+
+type codeStats struct {
+	count       int
+	first, last time.Time
+	intervals   []time.Duration
+	next        int
+}
+
+func NewStats() *Stats {
+	return &Stats{codes: make(map[string]*codeStats)}
+}
+
+func (s *Stats) Add(err error) {
+	if err != nil {
+		s.observe(CodeOf(err), Now())
+	}
+}
+
+func (s *Stats) Hook() Hook {
+	return s.Add
+}
+
+func (s *Stats) observe(code string, at time.Time) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	c, ok := s.codes[code]
+	if !ok {
+		if s.codes == nil {
+			s.codes = make(map[string]*codeStats)
+		}
+		s.codes[code] = &codeStats{count: 1, first: at, last: at}
+		return
+	}
+	gap := at.Sub(c.last)
+	if len(c.intervals) < statsWindow {
+		c.intervals = append(c.intervals, gap)
+	} else {
+		c.intervals[c.next] = gap
+		c.next = (c.next + 1) % statsWindow
+	}
+	c.count++
+	c.last = at
+}
+
+// Snapshot returns the statistics of every code seen, most frequent first.
+func (s *Stats) Snapshot() []CodeStats {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	out := make([]CodeStats, 0, len(s.codes))
+	for code, c := range s.codes {
+		sorted := append([]time.Duration(nil), c.intervals...)
+		sort.Slice(sorted, func(i, j int) bool { return sorted[i] < sorted[j] })
+		out = append(out, CodeStats{
+			Code:      code,
+			Count:     c.count,
+			FirstSeen: c.first,
+			LastSeen:  c.last,
+			P50:       percentile(sorted, 50),
+			P95:       percentile(sorted, 95),
+		})
+	}
+	sort.Slice(out, func(i, j int) bool {
+		if out[i].Count != out[j].Count {
+			return out[i].Count > out[j].Count
+		}
+		return out[i].Code < out[j].Code
+	})
+	return out
+}
+
+func percentile(sorted []time.Duration, p int) time.Duration {
+	if len(sorted) == 0 {
+		return 0
+	}
+	return sorted[(len(sorted)-1)*p/100]
+}
+
+func (s *Stats) MarshalJSON() ([]byte, error) {
+	return json.Marshal(s.Snapshot())
+}
+
+// WriteTable renders the snapshot as an aligned text table.
+func (s *Stats) WriteTable(w io.Writer) error {
+	tw := tabwriter.NewWriter(w, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(tw, "CODE\tCOUNT\tFIRST SEEN\tLAST SEEN\tP50\tP95")
+	for _, c := range s.Snapshot() {
+		fmt.Fprintf(tw, "%s\t%d\t%s\t%s\t%s\t%s\n", c.Code, c.Count,
+			c.FirstSeen.Format(time.RFC3339), c.LastSeen.Format(time.RFC3339), c.P50, c.P95)
+	}
+	return tw.Flush()
+}
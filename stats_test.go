@@ -0,0 +1,50 @@
+package baseError
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestStats(t *testing.T) {
+	s := NewStats()
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	for i, gap := range []time.Duration{0, time.Second, time.Second, 2 * time.Second, 10 * time.Second} {
+		start = start.Add(gap)
+		s.observe("DB_TIMEOUT", start)
+		if i < 2 {
+			s.observe("BAD_INPUT", start)
+		}
+	}
+	s.Add(New("ONCE", ""))
+
+	snap := s.Snapshot()
+	if len(snap) != 3 || snap[0].Code != "DB_TIMEOUT" || snap[0].Count != 5 {
+		t.Fatalf("snapshot = %+v", snap)
+	}
+	if snap[0].P50 != time.Second || snap[0].P95 != 2*time.Second {
+		t.Errorf("p50 = %v, p95 = %v", snap[0].P50, snap[0].P95)
+	}
+	if !snap[0].LastSeen.Equal(start) || snap[0].FirstSeen.After(snap[0].LastSeen) {
+		t.Errorf("seen = %v..%v", snap[0].FirstSeen, snap[0].LastSeen)
+	}
+
+	buf, err := json.Marshal(s)
+	if err != nil || !strings.Contains(string(buf), `"code":"DB_TIMEOUT","count":5`) {
+		t.Errorf("json = %s, %v", buf, err)
+	}
+	var b strings.Builder
+	s.WriteTable(&b)
+	if lines := strings.Split(strings.TrimSpace(b.String()), "\n"); len(lines) != 4 || !strings.HasPrefix(lines[1], "DB_TIMEOUT ") {
+		t.Errorf("table:\n%s", b.String())
+	}
+}
+
+func TestStatsZeroValue(t *testing.T) {
+	var s Stats
+	s.Add(New("ZERO", ""))
+	if snap := s.Snapshot(); len(snap) != 1 || snap[0].Count != 1 {
+		t.Errorf("snapshot = %v", snap)
+	}
+}
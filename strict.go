@@ -0,0 +1,39 @@
+package baseError
+
+import (
+	"fmt"
+	"net/http"
+)
+
+// SetStrict makes every constructor panic when it is misused, so that silent mistakes
+// fail tests immediately. It is meant for tests; see errtest.Strict. Misuse is:
+//   - an empty code;
+//   - a business error whose code is registered with a public code, which only System
+//     errors use, or with a 5xx HTTP status;
+//   - wrapping an error that already went through Sanitize, which has lost its cause.
+func SetStrict(on bool) {
+	updateConfig(func(c *Config) {
+		c.Strict = on
+	})
+}
+
+func checkStrict(e *Error) {
+	if e.Code == "" {
+		panic("baseError: error created with an empty code")
+	}
+	if !e.System {
+		if info, ok := e.registry().Lookup(e.Code); ok {
+			if info.PublicCode != "" {
+				panic(fmt.Sprintf("baseError: business error %s has a public code registered, which only applies to System errors", e.Code))
+			}
+			if info.HTTPStatus >= http.StatusInternalServerError {
+				panic(fmt.Sprintf("baseError: business error %s is registered with HTTP status %d", e.Code, info.HTTPStatus))
+			}
+		}
+	}
+	for inner := errorOf(e.cause); inner != nil; inner = errorOf(inner.cause) {
+		if inner.sanitized {
+			panic(fmt.Sprintf("baseError: %s wraps the sanitized error %s", e.Code, inner.Code))
+		}
+	}
+}
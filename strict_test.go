@@ -0,0 +1,44 @@
+package baseError
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestStrict(t *testing.T) {
+	prev := CurrentConfig()
+	defer Configure(prev)
+	RegisterPublic("STRICT_DB", "UNAVAILABLE", "try later")
+	RegisterHTTPStatus("STRICT_UPSTREAM", http.StatusBadGateway)
+
+	panics := func(fn func()) (msg string) {
+		defer func() {
+			if r := recover(); r != nil {
+				msg, _ = r.(string)
+			}
+		}()
+		fn()
+		return ""
+	}
+	if panics(func() { New("", "no code") }) != "" {
+		t.Fatal("not strict by default")
+	}
+
+	SetStrict(true)
+	for _, tc := range []struct {
+		fn   func()
+		want string
+	}{
+		{func() { New("", "no code") }, "empty code"},
+		{func() { New("STRICT_DB", "") }, "public code"},
+		{func() { Factory("STRICT_UPSTREAM")() }, "HTTP status 502"},
+		{func() { Wrap("STRICT_WRAP", Sanitize(System("STRICT_DB", ""))) }, "wraps the sanitized error UNAVAILABLE"},
+		{func() { System("STRICT_DB", "") }, ""},
+		{func() { Wrap("STRICT_WRAP", System("STRICT_DB", "")) }, ""},
+	} {
+		if got := panics(tc.fn); (tc.want == "") != (got == "") || !strings.Contains(got, tc.want) {
+			t.Errorf("panic = %q, want %q", got, tc.want)
+		}
+	}
+}
@@ -0,0 +1,78 @@
+package baseError
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+var ErrUnknownCode = errors.New("baseError: unknown code")
+
+// UnknownCodeError is returned by lookups of a code that is not registered. Suggestion is
+// the closest registered code, if any is close enough to be a likely typo.
+type UnknownCodeError struct {
+	Code       string
+	Suggestion string
+}
+
+func (e *UnknownCodeError) Error() string {
+	if e.Suggestion != "" {
+		return fmt.Sprintf("baseError: unknown code %q, did you mean %q?", e.Code, e.Suggestion)
+	}
+	return fmt.Sprintf("baseError: unknown code %q", e.Code)
+}
+
+func (e *UnknownCodeError) Is(target error) bool {
+	return target == ErrUnknownCode
+}
+
+// Get is Lookup returning an *UnknownCodeError with a suggestion for unknown codes.
+func (r *Registry) Get(code string) (CodeInfo, error) {
+	if info, ok := r.Lookup(code); ok {
+		return info, nil
+	}
+	return CodeInfo{}, &UnknownCodeError{Code: code, Suggestion: Suggest(code, r.Codes())}
+}
+
+// Suggest returns the candidate closest to code by case-insensitive edit distance, or ""
+// if none is within a third of code's length (at least two edits).
+func Suggest(code string, candidates []string) string {
+	limit := len(code) / 3
+	if limit < 2 {
+		limit = 2
+	}
+	best, bestDist := "", limit+1
+	for _, c := range candidates {
+		if d := levenshtein(strings.ToUpper(code), strings.ToUpper(c)); d < bestDist {
+			best, bestDist = c, d
+		}
+	}
+	return best
+}
+
+func levenshtein(a, b string) int {
+	ra, rb := []rune(a), []rune(b)
+	prev := make([]int, len(rb)+1)
+	cur := make([]int, len(rb)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(ra); i++ {
+		cur[0] = i
+		for j := 1; j <= len(rb); j++ {
+			cost := 1
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+			}
+			cur[j] = prev[j] + 1
+			if d := cur[j-1] + 1; d < cur[j] {
+				cur[j] = d
+			}
+			if d := prev[j-1] + cost; d < cur[j] {
+				cur[j] = d
+			}
+		}
+		prev, cur = cur, prev
+	}
+	return prev[len(rb)]
+}
@@ -0,0 +1,35 @@
+package baseError
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestRegistryGet(t *testing.T) {
+	r := NewRegistry()
+	r.SetLogLevel("USER_NOT_FOUND", LevelInfo)
+	r.SetLogLevel("ORDER_NOT_FOUND", LevelInfo)
+
+	if _, err := r.Get("USER_NOT_FOUND"); err != nil {
+		t.Fatal(err)
+	}
+	_, err := r.Get("USER_NOT_FUOND")
+	var u *UnknownCodeError
+	if !errors.As(err, &u) || u.Suggestion != "USER_NOT_FOUND" || !errors.Is(err, ErrUnknownCode) {
+		t.Errorf("err = %v", err)
+	}
+	if _, err := r.Get("PAYMENT_DECLINED"); err.(*UnknownCodeError).Suggestion != "" {
+		t.Errorf("err = %v", err)
+	}
+}
+
+func TestLevenshtein(t *testing.T) {
+	for _, c := range []struct {
+		a, b string
+		d    int
+	}{{"", "abc", 3}, {"kitten", "sitting", 3}, {"same", "same", 0}, {"日本", "日米", 1}} {
+		if got := levenshtein(c.a, c.b); got != c.d {
+			t.Errorf("levenshtein(%q, %q) = %d, want %d", c.a, c.b, got, c.d)
+		}
+	}
+}
@@ -0,0 +1,99 @@
+package baseError
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	CodeRunFailed = "RUN_FAILED"
+
+	FieldCounts   = "counts"
+	FieldDuration = "duration"
+)
+
+// Summary is the JSON-serializable digest of one run.
+type Summary struct {
+	Name     string         `json:"name"`
+	Duration time.Duration  `json:"duration"`
+	Total    int            `json:"total"`
+	Counts   map[string]int `json:"counts"`
+	Samples  []string       `json:"samples,omitempty"`
+}
+
+// Summarizer collects the errors of a scheduled run so that the run raises one alert
+// instead of one per failed item. The first error of each code is kept as a sample.
+type Summarizer struct {
+	name    string
+	start   time.Time
+	mu      sync.Mutex
+	total   int
+	counts  map[string]int
+	samples map[string]error
+}
+
+func NewSummarizer(name string) *Summarizer {
+	return &Summarizer{name: name, start: Now(), counts: make(map[string]int), samples: make(map[string]error)}
+}
+
+func (s *Summarizer) Add(err error) {
+	if isNil(err) {
+		return
+	}
+	code := CodeOf(err)
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.total++
+	s.counts[code]++
+	if _, ok := s.samples[code]; !ok {
+		s.samples[code] = err
+	}
+}
+
+func (s *Summarizer) Summary() Summary {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	sum := Summary{Name: s.name, Duration: Since(s.start), Total: s.total, Counts: make(map[string]int, len(s.counts))}
+	for _, code := range s.codesLocked() {
+		sum.Counts[code] = s.counts[code]
+		sum.Samples = append(sum.Samples, fmt.Sprintf("%+v", s.samples[code]))
+	}
+	return sum
+}
+
+// Err returns the digest error of the run, or nil when nothing failed. Sample stacks are
+// attached as debug details.
+func (s *Summarizer) Err() *Error {
+	sum := s.Summary()
+	if sum.Total == 0 {
+		return nil
+	}
+	s.mu.Lock()
+	parts := make([]string, 0, len(sum.Counts))
+	for _, code := range s.codesLocked() {
+		parts = append(parts, fmt.Sprintf("%s=%d", code, sum.Counts[code]))
+	}
+	s.mu.Unlock()
+	return System(CodeRunFailed, fmt.Sprintf("%s: %d errors (%s)", sum.Name, sum.Total, strings.Join(parts, ", "))).
+		WithField(FieldCounts, sum.Counts).
+		WithField(FieldDuration, sum.Duration).
+		WithDebugDetails(sum.Samples...)
+}
+
+// codesLocked returns the seen codes, most frequent first.
+func (s *Summarizer) codesLocked() []string {
+	codes := make([]string, 0, len(s.counts))
+	for code := range s.counts {
+		codes = append(codes, code)
+	}
+	sort.Slice(codes, func(i, j int) bool {
+		if s.counts[codes[i]] != s.counts[codes[j]] {
+			return s.counts[codes[i]] > s.counts[codes[j]]
+		}
+		return codes[i] < codes[j]
+	})
+	return codes
+}
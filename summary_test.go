@@ -0,0 +1,26 @@
+package baseError
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestSummarizer(t *testing.T) {
+	s := NewSummarizer("nightly-export")
+	if s.Err() != nil {
+		t.Fatal("empty run should not fail")
+	}
+	for i := 0; i < 3; i++ {
+		s.Add(New("ROW_INVALID", "row invalid"))
+	}
+	s.Add(errors.New("raw"))
+	s.Add(nil)
+
+	err := s.Err()
+	if err.Code != CodeRunFailed || err.Msg != "nightly-export: 4 errors (ROW_INVALID=3, UNKNOWN=1)" {
+		t.Errorf("got %v", err)
+	}
+	if sum := s.Summary(); sum.Total != 4 || len(sum.Samples) != 2 || sum.Counts["ROW_INVALID"] != 3 {
+		t.Errorf("summary = %+v", sum)
+	}
+}
@@ -0,0 +1,39 @@
+package baseError
+
+import "net/http"
+
+const (
+	CodeNotImplemented = "NOT_IMPLEMENTED"
+	CodeGone           = "GONE"
+
+	FieldFeature = "feature"
+	FieldSince   = "since"
+	FieldHelpURL = "help_url"
+)
+
+func init() {
+	RegisterHTTPStatus(CodeNotImplemented, http.StatusNotImplemented)
+	RegisterPublic(CodeNotImplemented, CodeNotImplemented, "not implemented")
+	RegisterHTTPStatus(CodeGone, http.StatusGone)
+}
+
+// NotImplemented reports a stubbed feature. It is a System error, since 501 is a server
+// failure, whose public form keeps the NOT_IMPLEMENTED code; the feature name only
+// reaches logs.
+func NotImplemented(feature string) *Error {
+	return SystemStack(CodeNotImplemented, feature+" is not implemented", AutoDepth).
+		WithField(FieldFeature, feature).
+		WithField(FieldHelpURL, HelpURL(CodeNotImplemented))
+}
+
+// GoneSince reports an endpoint removed in version, as a business error mapped to 410.
+func GoneSince(version string) *Error {
+	return created(&Error{Code: CodeGone, Msg: "removed since " + version}).
+		WithField(FieldSince, version).
+		WithField(FieldHelpURL, HelpURL(CodeGone))
+}
+
+// HelpURL returns the documentation URL of code, which is also its Problem type.
+func HelpURL(code string) string {
+	return cfg().ProblemTypeBase + code
+}
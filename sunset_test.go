@@ -0,0 +1,31 @@
+package baseError
+
+import (
+	"net/http"
+	"testing"
+)
+
+func TestNotImplemented(t *testing.T) {
+	e := NotImplemented("exports")
+	if !e.System || e.Msg != "exports is not implemented" || e.Fields()[FieldFeature] != "exports" || e.Stack() == nil {
+		t.Errorf("e = %+v", e)
+	}
+	if HTTPStatus(e) != http.StatusNotImplemented {
+		t.Errorf("status = %d", HTTPStatus(e))
+	}
+	p := ToProblem(e)
+	if p.Code != CodeNotImplemented || p.Status != http.StatusNotImplemented || p.Type != e.Fields()[FieldHelpURL] {
+		t.Errorf("problem = %+v", p)
+	}
+}
+
+func TestGoneSince(t *testing.T) {
+	e := GoneSince("v2")
+	if e.System || e.Msg != "removed since v2" || HTTPStatus(e) != http.StatusGone {
+		t.Errorf("e = %+v", e)
+	}
+	p := ToProblem(e)
+	if p.Extensions[FieldSince] != "v2" || p.Extensions[FieldHelpURL] != "/problems/GONE" {
+		t.Errorf("problem = %+v", p)
+	}
+}
@@ -0,0 +1,59 @@
+package baseError
+
+import (
+	"sync"
+	"time"
+)
+
+var suppressions struct {
+	sync.Mutex
+	keys map[string]time.Time
+}
+
+// Suppress mutes reporting and logging of errors whose code or fingerprint equals key for
+// ttl. A ttl of zero mutes until Unsuppress is called.
+func Suppress(key string, ttl time.Duration) {
+	var until time.Time
+	if ttl > 0 {
+		until = Now().Add(ttl)
+	}
+	suppressions.Lock()
+	defer suppressions.Unlock()
+	if suppressions.keys == nil {
+		suppressions.keys = make(map[string]time.Time)
+	}
+	suppressions.keys[key] = until
+}
+
+func Unsuppress(key string) {
+	suppressions.Lock()
+	defer suppressions.Unlock()
+	delete(suppressions.keys, key)
+}
+
+func Suppressed(err error) bool {
+	if isNil(err) {
+		return false
+	}
+	suppressions.Lock()
+	defer suppressions.Unlock()
+	if len(suppressions.keys) == 0 {
+		return false
+	}
+	if suppressedLocked(CodeOf(err)) {
+		return true
+	}
+	return suppressedLocked(Fingerprint(err))
+}
+
+func suppressedLocked(key string) bool {
+	until, ok := suppressions.keys[key]
+	if !ok {
+		return false
+	}
+	if !until.IsZero() && Now().After(until) {
+		delete(suppressions.keys, key)
+		return false
+	}
+	return true
+}
@@ -0,0 +1,36 @@
+package baseError
+
+import (
+	"testing"
+	"time"
+)
+
+func TestSuppress(t *testing.T) {
+	var reported int
+	AddHook(func(err error) {
+		if CodeOf(err) == "NOISY" {
+			reported++
+		}
+	})
+
+	Suppress("NOISY", 0)
+	Report(New("NOISY", ""))
+	if reported != 0 || ShouldLog(System("NOISY", ""), LevelDebug) {
+		t.Fatal("suppressed error was reported")
+	}
+	Unsuppress("NOISY")
+	Report(New("NOISY", ""))
+	if reported != 1 {
+		t.Fatalf("reported = %d", reported)
+	}
+
+	err := NewStack("NOISY", "", 5)
+	Suppress(Fingerprint(err), time.Millisecond)
+	if !Suppressed(err) {
+		t.Error("expected fingerprint to be suppressed")
+	}
+	time.Sleep(2 * time.Millisecond)
+	if Suppressed(err) {
+		t.Error("expected suppression to expire")
+	}
+}
@@ -0,0 +1,124 @@
+package baseError
+
+import (
+	"fmt"
+	"strings"
+)
+
+// isPlaceholderName reports whether s can name a placeholder: letters, digits,
+// underscores and dots.
+func isPlaceholderName(s string) bool {
+	if s == "" {
+		return false
+	}
+	for _, r := range s {
+		if r != '_' && r != '.' && !('a' <= r && r <= 'z') && !('A' <= r && r <= 'Z') && !('0' <= r && r <= '9') {
+			return false
+		}
+	}
+	return true
+}
+
+// parseNamed splits template around its {} and {name} placeholders. It returns no names
+// when the template has no named placeholder, leaving it to the positional formatter.
+func parseNamed(template string) (parts []string, names []string) {
+	named := false
+	var text strings.Builder
+	for rest := template; rest != ""; {
+		open := strings.IndexByte(rest, '{')
+		if open < 0 {
+			text.WriteString(rest)
+			break
+		}
+		close := strings.IndexByte(rest[open:], '}')
+		if close < 0 {
+			text.WriteString(rest)
+			break
+		}
+		name := rest[open+1 : open+close]
+		if name != "" && !isPlaceholderName(name) {
+			text.WriteString(rest[:open+close+1])
+			rest = rest[open+close+1:]
+			continue
+		}
+		named = named || name != ""
+		text.WriteString(rest[:open])
+		parts = append(parts, text.String())
+		names = append(names, name)
+		text.Reset()
+		rest = rest[open+close+1:]
+	}
+	if !named {
+		return nil, nil
+	}
+	return append(parts, text.String()), names
+}
+
+// namedFormatter fills the placeholders of a template split by parseNamed. A leading
+// map[string]interface{} argument fills {name} placeholders and the arguments after it
+// fill {} placeholders in order. Without a map, placeholders take positional arguments
+// in order, a repeated name taking the value of its first occurrence. Missing values
+// render empty, like Factory's.
+//
+// It also returns the arguments in the map form, which is what the error records for
+// Localize: locale messages then get every {name} right even when they reorder them.
+func namedFormatter(parts []string, names []string) func(message ...interface{}) (string, []interface{}) {
+	return func(message ...interface{}) (string, []interface{}) {
+		args := bindNames(names, message)
+		values, rest := args[0].(map[string]interface{}), args[1:]
+		var b strings.Builder
+		next := 0
+		for i, name := range names {
+			b.WriteString(parts[i])
+			if name != "" {
+				if v, ok := values[name]; ok {
+					fmt.Fprint(&b, v)
+				}
+				continue
+			}
+			if next < len(rest) {
+				fmt.Fprint(&b, rest[next])
+				next++
+			}
+		}
+		b.WriteString(parts[len(names)])
+		return b.String(), args
+	}
+}
+
+// bindNames returns message in the form namedFormatter fills names from: a leading map
+// of the named values followed by the values of the {} placeholders. Positional
+// arguments are assigned to the placeholders in order.
+func bindNames(names []string, message []interface{}) []interface{} {
+	if len(message) > 0 {
+		if _, ok := message[0].(map[string]interface{}); ok {
+			return message
+		}
+	}
+	values := make(map[string]interface{}, len(names))
+	args := []interface{}{values}
+	next := 0
+	for _, name := range names {
+		if _, ok := values[name]; ok && name != "" {
+			continue
+		}
+		if next == len(message) {
+			break
+		}
+		if name == "" {
+			args = append(args, message[next])
+		} else {
+			values[name] = message[next]
+		}
+		next++
+	}
+	return args
+}
+
+// placeholderCount counts the {} and {name} placeholders of template.
+func placeholderCount(template string) int {
+	if _, names := parseNamed(template); names != nil {
+		return len(names)
+	}
+	return strings.Count(template, "{}")
+}
@@ -0,0 +1,48 @@
+package baseError
+
+import "testing"
+
+func TestNamedPlaceholders(t *testing.T) {
+	notFound := Factory("NAMED_USER_NOT_FOUND", "user {id} not found in {region}")
+	cases := []struct {
+		args []interface{}
+		want string
+	}{
+		{[]interface{}{map[string]interface{}{"region": "eu", "id": 7}}, "user 7 not found in eu"},
+		{[]interface{}{map[string]interface{}{"id": 7}}, "user 7 not found in "},
+		{[]interface{}{7, "eu"}, "user 7 not found in eu"},
+		{nil, "user  not found in "},
+	}
+	for _, c := range cases {
+		if got := notFound(c.args...).Msg; got != c.want {
+			t.Errorf("notFound(%v) = %q, want %q", c.args, got, c.want)
+		}
+	}
+
+	if got := Factory("NAMED_MIXED", "{} of {total}, {bad key} left")(3, 9).Msg; got != "3 of 9, {bad key} left" {
+		t.Errorf("mixed = %q", got)
+	}
+	if got := Factory("NAMED_POSITIONAL", "{} and {}")(1, 2).Msg; got != "1 and 2" {
+		t.Errorf("positional = %q", got)
+	}
+
+	RegisterMessage("NAMED_USER_NOT_FOUND", "de", "In {region} gibt es keinen Benutzer {id}")
+	e := notFound(map[string]interface{}{"id": 7, "region": "eu"})
+	if got := e.Localize("de"); got != "In eu gibt es keinen Benutzer 7" {
+		t.Errorf("localized = %q", got)
+	}
+	if got := notFound(7, "eu").Localize("de"); got != "In eu gibt es keinen Benutzer 7" {
+		t.Errorf("localized positional = %q", got)
+	}
+	if got := Factory("NAMED_REPEATED", "{id}: {} is not {id}")(7, "x").Msg; got != "7: x is not 7" {
+		t.Errorf("repeated = %q", got)
+	}
+}
+
+func TestPlaceholderCount(t *testing.T) {
+	for tmpl, want := range map[string]int{"{} and {}": 2, "{id} in {region}": 2, "{} of {total}": 2, "none": 0} {
+		if got := placeholderCount(tmpl); got != want {
+			t.Errorf("placeholderCount(%q) = %d, want %d", tmpl, got, want)
+		}
+	}
+}
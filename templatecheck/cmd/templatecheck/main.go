@@ -0,0 +1,14 @@
+// Command templatecheck runs the templatecheck analyzer standalone or as a vet tool:
+//
+//	templatecheck -banned=oops,todo ./...
+//	go vet -vettool=$(which templatecheck) ./...
+package main
+
+import (
+	"github.com/go-tron/base-error/templatecheck"
+	"golang.org/x/tools/go/analysis/singlechecker"
+)
+
+func main() {
+	singlechecker.Main(templatecheck.Analyzer)
+}
@@ -0,0 +1,17 @@
+module github.com/go-tron/base-error/templatecheck
+
+go 1.26.0
+
+require (
+	github.com/go-tron/base-error v0.0.0-00010101000000-000000000000
+	golang.org/x/tools v0.50.0
+)
+
+require (
+	github.com/pkg/errors v0.9.1 // indirect
+	golang.org/x/mod v0.41.0 // indirect
+	golang.org/x/sync v0.23.0 // indirect
+	golang.org/x/text v0.14.0 // indirect
+)
+
+replace github.com/go-tron/base-error => ../
@@ -0,0 +1,80 @@
+// Package templatecheck is a go/analysis pass running baseError.LintTemplate on the
+// constant message templates given to factories and registries, so unbalanced braces,
+// stray printf verbs and banned words fail vet instead of a RegistryValidate test.
+// Whether the locales of a code agree on their placeholders depends on every
+// registration of the program and is left to RegistryValidate.
+package templatecheck
+
+import (
+	"go/ast"
+	"go/constant"
+	"go/types"
+	"strings"
+
+	baseError "github.com/go-tron/base-error"
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/analysis/passes/inspect"
+	"golang.org/x/tools/go/ast/inspector"
+	"golang.org/x/tools/go/types/typeutil"
+)
+
+const basePath = "github.com/go-tron/base-error"
+
+var Analyzer = &analysis.Analyzer{
+	Name:     "templatecheck",
+	Doc:      "check baseError message templates with LintTemplate",
+	Requires: []*analysis.Analyzer{inspect.Analyzer},
+	Run:      run,
+}
+
+var banned string
+
+func init() {
+	Analyzer.Flags.StringVar(&banned, "banned", "", "comma-separated words templates must not contain")
+}
+
+// templateArg is the index of the template argument of each function or method of
+// baseError taking one.
+var templateArg = map[string]int{
+	"Factory":            1,
+	"FactoryStack":       2,
+	"SystemFactory":      1,
+	"SystemFactoryStack": 2,
+	"Factory1":           1,
+	"Factory2":           1,
+	"Factory3":           1,
+	"SystemFactory1":     1,
+	"SystemFactory2":     1,
+	"SystemFactory3":     1,
+	"RegisterTemplate":   1,
+	"SetTemplate":        1,
+	"RegisterMessage":    2,
+	"SetMessage":         2,
+}
+
+func run(pass *analysis.Pass) (interface{}, error) {
+	var words []string
+	if banned != "" {
+		words = strings.Split(banned, ",")
+	}
+	insp := pass.ResultOf[inspect.Analyzer].(*inspector.Inspector)
+	insp.Preorder([]ast.Node{(*ast.CallExpr)(nil)}, func(n ast.Node) {
+		call := n.(*ast.CallExpr)
+		fn, ok := typeutil.Callee(pass.TypesInfo, call).(*types.Func)
+		if !ok || fn.Pkg() == nil || fn.Pkg().Path() != basePath {
+			return
+		}
+		i, ok := templateArg[fn.Name()]
+		if !ok || i >= len(call.Args) {
+			return
+		}
+		tv, ok := pass.TypesInfo.Types[call.Args[i]]
+		if !ok || tv.Value == nil || tv.Value.Kind() != constant.String {
+			return
+		}
+		if err := baseError.LintTemplate(constant.StringVal(tv.Value), words...); err != nil {
+			pass.Reportf(call.Args[i].Pos(), "%s template: %v", fn.Name(), err)
+		}
+	})
+	return nil, nil
+}
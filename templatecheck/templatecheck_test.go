@@ -0,0 +1,15 @@
+package templatecheck
+
+import (
+	"testing"
+
+	"golang.org/x/tools/go/analysis/analysistest"
+)
+
+func TestAnalyzer(t *testing.T) {
+	if err := Analyzer.Flags.Set("banned", "oops"); err != nil {
+		t.Fatal(err)
+	}
+	defer Analyzer.Flags.Set("banned", "")
+	analysistest.Run(t, analysistest.TestData(), Analyzer, "a")
+}
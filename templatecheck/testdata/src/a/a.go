@@ -0,0 +1,22 @@
+package a
+
+import baseError "github.com/go-tron/base-error"
+
+const orderTemplate = "order {id} not found"
+
+var (
+	ErrOrderNotFound = baseError.Factory("ORDER_NOT_FOUND", orderTemplate)
+	ErrUserNotFound  = baseError.Factory("USER_NOT_FOUND", "user %s not found") // want `Factory template: stray verb "%s"`
+	ErrTimeout       = baseError.FactoryStack(5, "TIMEOUT", "timed out after {}")
+	ErrUnbalanced    = baseError.FactoryStack(5, "UNBALANCED", "bad {id")       // want `FactoryStack template: unclosed '\{'`
+	ErrTyped         = baseError.Factory1[int]("TYPED", "item {bad key}")       // want `Factory1 template: invalid placeholder "\{bad key\}"`
+	ErrBanned        = baseError.Factory("BANNED", "oops, stack trace follows") // want `Factory template: banned word "oops"`
+	ErrCodeOnly      = baseError.Factory("CODE_ONLY")
+)
+
+func register(r *baseError.Registry, dynamic string) {
+	baseError.RegisterTemplate("ORDER_NOT_FOUND", "order {} not found}") // want `RegisterTemplate template: unmatched '\}'`
+	r.SetMessage("ORDER_NOT_FOUND", "de", "Bestellung {id} nicht gefunden")
+	r.SetMessage("ORDER_NOT_FOUND", "fr", "commande %d introuvable") // want `SetMessage template: stray verb "%d"`
+	r.SetMessage("ORDER_NOT_FOUND", "it", dynamic)
+}
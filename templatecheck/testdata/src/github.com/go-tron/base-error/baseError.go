@@ -0,0 +1,16 @@
+// Package baseError stubs the factories and registries templatecheck looks at.
+package baseError
+
+type Error struct{}
+
+type Registry struct{}
+
+func Factory(arg ...string) func(...interface{}) *Error { return nil }
+
+func FactoryStack(depth int, arg ...string) func(...interface{}) *Error { return nil }
+
+func Factory1[T any](code string, msg string) func(T) *Error { return nil }
+
+func RegisterTemplate(code string, template string) {}
+
+func (r *Registry) SetMessage(code string, locale string, template string) {}
@@ -0,0 +1,54 @@
+package baseError
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"runtime"
+	"runtime/trace"
+	"strings"
+)
+
+// SetTraceConstruction switches construction tracing on or off at runtime. While on,
+// every error created logs its code and the first caller outside this module to
+// Config.TraceWriter (stderr by default) and, when a runtime/trace is being recorded, as
+// a "baseError.created" log event.
+func SetTraceConstruction(on bool) {
+	updateConfig(func(c *Config) {
+		c.TraceConstruction = on
+	})
+}
+
+func traceConstruction(e *Error) {
+	c := e.cfg()
+	if !c.TraceConstruction {
+		return
+	}
+	site := constructionSite()
+	if trace.IsEnabled() {
+		trace.Log(context.Background(), "baseError.created", e.Code+" "+site)
+	}
+	var w io.Writer = os.Stderr
+	if c.TraceWriter != nil {
+		w = c.TraceWriter
+	}
+	fmt.Fprintf(w, "baseError: created [%s] at %s\n", e.Code, site)
+}
+
+func constructionSite() string {
+	for i := 3; i < 32; i++ {
+		pc, file, line, ok := runtime.Caller(i)
+		if !ok {
+			break
+		}
+		if !inSourceDirs(file) || strings.HasSuffix(file, "_test.go") {
+			name := "?"
+			if fn := runtime.FuncForPC(pc); fn != nil {
+				name = fn.Name()
+			}
+			return fmt.Sprintf("%s %s:%d", name, file, line)
+		}
+	}
+	return "unknown"
+}
@@ -0,0 +1,29 @@
+package baseError
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestTraceConstruction(t *testing.T) {
+	prev := CurrentConfig()
+	defer Configure(prev)
+	var b strings.Builder
+	c := CurrentConfig()
+	c.TraceWriter = &b
+	Configure(c)
+
+	New("QUIET", "")
+	SetTraceConstruction(true)
+	Wrap("TRACED", New("INNER", ""))
+	SetTraceConstruction(false)
+	New("QUIET", "")
+
+	lines := strings.Split(strings.TrimSpace(b.String()), "\n")
+	if len(lines) != 2 || !strings.HasPrefix(lines[0], "baseError: created [INNER] at ") || !strings.Contains(lines[1], "[TRACED] at ") {
+		t.Fatalf("trace:\n%s", b.String())
+	}
+	if !strings.Contains(lines[1], "TestTraceConstruction") || !strings.Contains(lines[1], "trace_test.go:") {
+		t.Errorf("caller missing: %s", lines[1])
+	}
+}
@@ -0,0 +1,45 @@
+package baseError
+
+import (
+	"unicode"
+	"unicode/utf8"
+
+	"golang.org/x/text/unicode/norm"
+)
+
+const zeroWidthJoiner = '\u200d'
+
+// Truncate shortens s to at most n bytes without splitting a character: the cut never
+// falls inside a UTF-8 sequence, before a combining mark or variation selector, or
+// right after a zero width joiner. Invalid input is cut on byte boundaries as is.
+func Truncate(s string, n int) string {
+	if n >= len(s) {
+		return s
+	}
+	if n <= 0 {
+		return ""
+	}
+	cut := n
+	for cut > 0 && !utf8.RuneStart(s[cut]) {
+		cut--
+	}
+	for cut > 0 {
+		next, _ := utf8.DecodeRuneInString(s[cut:])
+		prev, size := utf8.DecodeLastRuneInString(s[:cut])
+		if !extendsCluster(next) && prev != zeroWidthJoiner {
+			break
+		}
+		cut -= size
+	}
+	return s[:cut]
+}
+
+func extendsCluster(r rune) bool {
+	return r == zeroWidthJoiner || unicode.In(r, unicode.Mn, unicode.Me, unicode.Mc, unicode.Variation_Selector)
+}
+
+func normalize(e *Error) {
+	if e.cfg().NormalizeMessages && !norm.NFC.IsNormalString(e.Msg) {
+		e.Msg = norm.NFC.String(e.Msg)
+	}
+}
@@ -0,0 +1,50 @@
+package baseError
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+	"unicode/utf8"
+)
+
+func TestTruncate(t *testing.T) {
+	for _, c := range []struct {
+		s    string
+		n    int
+		want string
+	}{
+		{"hello", 10, "hello"},
+		{"hello", 3, "hel"},
+		{"日本語", 4, "日"},
+		{"cafe\u0301s", 5, "caf"},
+		{"ok\U0001F469\u200d\U0001F4BB", 9, "ok"},
+		{"a\u2764\ufe0f", 4, "a"},
+	} {
+		if got := Truncate(c.s, c.n); got != c.want {
+			t.Errorf("Truncate(%q, %d) = %q, want %q", c.s, c.n, got, c.want)
+		}
+	}
+}
+
+func TestMarshalLimitedUnicode(t *testing.T) {
+	err := New("LOCALIZED", strings.Repeat("ошибка ", 30))
+	buf, _ := MarshalLimited(err, 80)
+	var out struct{ Msg string }
+	if e := json.Unmarshal(buf, &out); e != nil || len(buf) > 80 {
+		t.Fatalf("len=%d err=%v", len(buf), e)
+	}
+	if !utf8.ValidString(out.Msg) || strings.ContainsRune(out.Msg, utf8.RuneError) {
+		t.Errorf("message cut mid-character: %q", out.Msg)
+	}
+}
+
+func TestNormalizeMessages(t *testing.T) {
+	prev := CurrentConfig()
+	defer Configure(prev)
+	c := CurrentConfig()
+	c.NormalizeMessages = true
+	Configure(c)
+	if e := New("X", "cafe\u0301"); e.Msg != "caf\u00e9" {
+		t.Errorf("msg = %q", e.Msg)
+	}
+}
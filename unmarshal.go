@@ -0,0 +1,90 @@
+package baseError
+
+import (
+	"encoding/json"
+)
+
+type wireError struct {
+	Code    string                 `json:"code"`
+	Type    string                 `json:"type"`
+	Msg     string                 `json:"msg"`
+	System  bool                   `json:"system"`
+	Chain   string                 `json:"chain"`
+	Ref     string                 `json:"ref"`
+	Fields  map[string]interface{} `json:"fields"`
+	Details []string               `json:"details"`
+	Debug   []string               `json:"debug"`
+	Origin  Origin                 `json:"origin"`
+	Causes  []wireError            `json:"causes"`
+}
+
+// remoteError stands in for a foreign error received in a cause chain.
+type remoteError struct {
+	typ string
+	msg string
+}
+
+func (r *remoteError) Error() string {
+	return r.msg
+}
+
+// RemoteType returns the Go type the error had on the sending side.
+func (r *remoteError) RemoteType() string {
+	return r.typ
+}
+
+// UnmarshalJSON rebuilds an error from the output of MarshalJSON or EncodeJSON: code,
+// message, System flag, chain, fields, details, origin and nested causes. Stacks are not
+// restored; foreign causes come back as errors with the original message.
+func (b *Error) UnmarshalJSON(data []byte) error {
+	var w wireError
+	if err := json.Unmarshal(data, &w); err != nil {
+		return err
+	}
+	*b = *w.toError()
+	return nil
+}
+
+// Parse decodes an error produced by MarshalJSON or EncodeJSON.
+func Parse(data []byte) (*Error, error) {
+	e := new(Error)
+	if err := e.UnmarshalJSON(data); err != nil {
+		return nil, err
+	}
+	return e, nil
+}
+
+func (w *wireError) toError() *Error {
+	e := &Error{
+		Code:         w.Code,
+		Msg:          w.Msg,
+		System:       w.System,
+		Chain:        w.Chain,
+		safeDetails:  w.Details,
+		debugDetails: w.Debug,
+		origin:       w.Origin,
+	}
+	e.WithFields(w.Fields)
+	if w.Ref != "" {
+		e.WithField(FieldReference, w.Ref)
+	}
+	causes := make([]error, 0, len(w.Causes))
+	for i := range w.Causes {
+		causes = append(causes, w.Causes[i].toCause())
+	}
+	switch len(causes) {
+	case 0:
+	case 1:
+		e.cause = causes[0]
+	default:
+		e.cause = &joined{errs: causes}
+	}
+	return e
+}
+
+func (w *wireError) toCause() error {
+	if w.Code == "" {
+		return &remoteError{typ: w.Type, msg: w.Msg}
+	}
+	return w.toError()
+}
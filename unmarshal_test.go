@@ -0,0 +1,40 @@
+package baseError
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"testing"
+)
+
+func TestParse(t *testing.T) {
+	sent := Wrap("LOAD_FAILED", Join("FANOUT", New("SHARD_A", "a down"), io.EOF)).
+		WithChain("api", "loader").
+		WithField("user", "u-1")
+	buf, _ := EncodeJSON(sent, EncodeOptions{Causes: true})
+
+	got, err := Parse(buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.Code != "LOAD_FAILED" || !got.System || got.Chain != "api<-loader" || got.Fields()["user"] != "u-1" {
+		t.Errorf("got %+v", got)
+	}
+	if !errors.Is(got, CodeError("SHARD_A")) || !Equal(got, sent) {
+		t.Errorf("causes not rebuilt: %+v", got)
+	}
+	if causes := got.Unwrap().(*Error).Causes(); len(causes) != 2 || causes[1].Error() != "EOF" {
+		t.Errorf("causes = %v", causes)
+	}
+
+	var e Error
+	if err := json.Unmarshal([]byte(`{"code":"BAD_INPUT","msg":"bad","ref":"r-1","details":["field=email"]}`), &e); err != nil {
+		t.Fatal(err)
+	}
+	if e.Code != "BAD_INPUT" || e.System || e.Fields()[FieldReference] != "r-1" || e.SafeDetails()[0] != "field=email" {
+		t.Errorf("got %+v", &e)
+	}
+	if _, err := Parse([]byte(`{`)); err == nil {
+		t.Error("expected a syntax error")
+	}
+}
@@ -0,0 +1,80 @@
+package baseError
+
+type Kind int8
+
+const (
+	KindBusiness Kind = iota + 1
+	KindSystem
+	KindForeign
+)
+
+func (k Kind) String() string {
+	switch k {
+	case KindBusiness:
+		return "business"
+	case KindSystem:
+		return "system"
+	case KindForeign:
+		return "foreign"
+	}
+	return ""
+}
+
+// ErrorView is a read-only snapshot of an error for untrusted in-process code such as
+// plugins. It can't be unwrapped back to the *Error and doesn't expose the internal
+// message or fields of System errors, causes, stacks, debug details, or the fields
+// ToProblem keeps from clients, such as those holding other errors.
+type ErrorView interface {
+	Code() string
+	// PublicMsg is the message Sanitize exposes.
+	PublicMsg() string
+	Kind() Kind
+	// Fields returns a copy of the public fields of a business error; values themselves
+	// are not copied.
+	Fields() map[string]interface{}
+}
+
+type view struct {
+	code, msg string
+	kind      Kind
+	fields    map[string]interface{}
+}
+
+// View returns the ErrorView of err, or nil if err is nil. Unlike Sanitize it doesn't
+// stamp a reference on err.
+func View(err error) ErrorView {
+	if isNil(err) {
+		return nil
+	}
+	e := errorOf(err)
+	if e == nil {
+		return &view{code: CodeOf(err), msg: cfg().SanitizedMsg, kind: KindForeign}
+	}
+	v := &view{code: e.Code, msg: publicMsg(e), kind: KindSystem}
+	if e.System {
+		return v
+	}
+	v.kind = KindBusiness
+	private := e.privateFields()
+	for k, x := range e.Fields() {
+		if !private[k] {
+			if v.fields == nil {
+				v.fields = make(map[string]interface{})
+			}
+			v.fields[k] = x
+		}
+	}
+	return v
+}
+
+func (v *view) Code() string      { return v.code }
+func (v *view) PublicMsg() string { return v.msg }
+func (v *view) Kind() Kind        { return v.kind }
+
+func (v *view) Fields() map[string]interface{} {
+	out := make(map[string]interface{}, len(v.fields))
+	for k, x := range v.fields {
+		out[k] = x
+	}
+	return out
+}
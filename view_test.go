@@ -0,0 +1,44 @@
+package baseError
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+)
+
+func TestView(t *testing.T) {
+	e := System("VIEW_DB", "password=hunter2").WithField("table", "users").WithDebugDetails("dsn=secret")
+	v := View(fmt.Errorf("save: %w", e))
+	if v.Code() != "VIEW_DB" || v.PublicMsg() != CurrentConfig().SanitizedMsg || v.Kind() != KindSystem || v.Kind().String() != "system" {
+		t.Errorf("view = %v %q %v", v.Code(), v.PublicMsg(), v.Kind())
+	}
+	if len(v.Fields()) != 0 {
+		t.Errorf("system view fields = %v", v.Fields())
+	}
+	if _, ok := e.Fields()[FieldReference]; ok {
+		t.Error("View stamped a reference")
+	}
+	if _, ok := v.(error); ok {
+		t.Error("view is an error")
+	}
+
+	b := New("VIEW_BAD", "bad input").WithField("table", "users").
+		WithField("cause", e).WithField(FieldSuppressed, []*Error{e})
+	v = View(b)
+	if v.PublicMsg() != "bad input" || v.Kind() != KindBusiness {
+		t.Errorf("business view = %q %v", v.PublicMsg(), v.Kind())
+	}
+	if f := v.Fields(); len(f) != 1 || f["table"] != "users" {
+		t.Errorf("business view fields = %v", f)
+	}
+	v.Fields()["table"] = "orders"
+	if b.Fields()["table"] != "users" || v.Fields()["table"] != "users" {
+		t.Error("view fields alias the error")
+	}
+	if v := View(errors.New("raw")); v.Code() != DefaultWrapCode() || v.Kind() != KindForeign || v.PublicMsg() == "raw" {
+		t.Errorf("foreign view = %v %q", v.Code(), v.PublicMsg())
+	}
+	if View(nil) != nil {
+		t.Error("nil viewed")
+	}
+}
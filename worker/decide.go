@@ -0,0 +1,115 @@
+package worker
+
+import (
+	"errors"
+	"math"
+	"sync"
+	"time"
+
+	baseError "github.com/go-tron/base-error"
+)
+
+// FieldRetryAfter is a time.Duration hint, typically from an upstream Retry-After, that
+// Decide never retries sooner than.
+const FieldRetryAfter = "retry_after"
+
+// Decision is the disposition of a failed message; After is the redelivery delay of a
+// Retry.
+type Decision struct {
+	Action Action
+	After  time.Duration
+}
+
+// Policy decides the disposition of failures on one queue.
+type Policy struct {
+	// MaxAttempts is the attempt after which retryable failures are dead-lettered.
+	MaxAttempts int
+	// Backoff is the delay before the first retry, doubled on every further attempt up to
+	// MaxBackoff.
+	Backoff    time.Duration
+	MaxBackoff time.Duration
+	// DeadLetterCodes are dead-lettered on first failure even if retryable.
+	DeadLetterCodes []string
+}
+
+var DefaultPolicy = &Policy{MaxAttempts: 5, Backoff: time.Second, MaxBackoff: 5 * time.Minute}
+
+var policies sync.Map
+
+// SetPolicy sets the policy Decide and Wrap apply to failures on queue. A nil p removes
+// it, so the queue falls back to DefaultPolicy.
+func SetPolicy(queue string, p *Policy) {
+	if p == nil {
+		policies.Delete(queue)
+		return
+	}
+	policies.Store(queue, p)
+}
+
+// PolicyFor returns the policy set for queue, or DefaultPolicy.
+func PolicyFor(queue string) *Policy {
+	if v, ok := policies.Load(queue); ok {
+		return v.(*Policy)
+	}
+	return DefaultPolicy
+}
+
+// Decide picks the policy of the queue recorded on err, or DefaultPolicy, and applies it.
+func Decide(err error) Decision {
+	var queue string
+	var e *baseError.Error
+	if errors.As(err, &e) {
+		queue, _ = e.Fields()[FieldQueue].(string)
+	}
+	return PolicyFor(queue).Decide(err)
+}
+
+// Decide acks nil errors, a nil *baseError.Error included, dead-letters non-retryable ones, those with a dead-letter code
+// and those whose attempt field has reached MaxAttempts, and retries the rest after an
+// exponential backoff or the error's retry_after hint, whichever is longer.
+func (p *Policy) Decide(err error) Decision {
+	if e, ok := err.(*baseError.Error); err == nil || ok && e == nil {
+		return Decision{Action: Ack}
+	}
+	if !baseError.IsRetryable(err) {
+		return Decision{Action: DeadLetter}
+	}
+	code := baseError.CodeOf(err)
+	for _, c := range p.DeadLetterCodes {
+		if c == code {
+			return Decision{Action: DeadLetter}
+		}
+	}
+
+	attempt := 1
+	var hint time.Duration
+	var e *baseError.Error
+	if errors.As(err, &e) {
+		if n := e.Attempt(); n > 0 {
+			attempt = n
+		}
+		hint, _ = e.Fields()[FieldRetryAfter].(time.Duration)
+	}
+	if p.MaxAttempts > 0 && attempt >= p.MaxAttempts {
+		return Decision{Action: DeadLetter}
+	}
+	after := p.backoff(attempt)
+	if hint > after {
+		after = hint
+	}
+	return Decision{Action: Retry, After: after}
+}
+
+func (p *Policy) backoff(attempt int) time.Duration {
+	d := p.Backoff
+	for i := 1; i < attempt && d > 0 && (p.MaxBackoff <= 0 || d < p.MaxBackoff); i++ {
+		if d > math.MaxInt64/2 {
+			return math.MaxInt64
+		}
+		d *= 2
+	}
+	if p.MaxBackoff > 0 && d > p.MaxBackoff {
+		d = p.MaxBackoff
+	}
+	return d
+}
@@ -0,0 +1,53 @@
+package worker
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	baseError "github.com/go-tron/base-error"
+)
+
+func TestDecide(t *testing.T) {
+	SetPolicy("test-payments", &Policy{MaxAttempts: 3, Backoff: time.Second, MaxBackoff: 3 * time.Second, DeadLetterCodes: []string{"TEST_FRAUD"}})
+	busy := func(attempt int) *baseError.Error {
+		return baseError.System("TEST_BUSY", "busy").WithRetryable(true).
+			WithField(FieldQueue, "test-payments").WithField(FieldAttempt, attempt)
+	}
+
+	cases := []struct {
+		err  error
+		want Decision
+	}{
+		{nil, Decision{Action: Ack}},
+		{(*baseError.Error)(nil), Decision{Action: Ack}},
+		{baseError.New("BAD_PAYLOAD", ""), Decision{Action: DeadLetter}},
+		{busy(1), Decision{Action: Retry, After: time.Second}},
+		{busy(2), Decision{Action: Retry, After: 2 * time.Second}},
+		{busy(3), Decision{Action: DeadLetter}},
+		{busy(1).WithField(FieldRetryAfter, 30*time.Second), Decision{Action: Retry, After: 30 * time.Second}},
+		{baseError.System("TEST_FRAUD", "").WithRetryable(true).WithField(FieldQueue, "test-payments"), Decision{Action: DeadLetter}},
+		{baseError.System("TEST_BUSY", "").WithRetryable(true).WithField(FieldAttempt, 4), Decision{Action: Retry, After: 8 * time.Second}},
+		{errors.New("raw"), Decision{Action: DeadLetter}},
+		{busy(2).WithField(FieldAttempt, float64(3)), Decision{Action: DeadLetter}},
+	}
+	for i, c := range cases {
+		if got := Decide(c.err); got != c.want {
+			t.Errorf("%d: Decide(%v) = %+v, want %+v", i, c.err, got, c.want)
+		}
+	}
+}
+
+func TestPolicyBackoff(t *testing.T) {
+	p := &Policy{Backoff: time.Second}
+	if d := p.backoff(100); d <= 0 {
+		t.Errorf("backoff(100) = %v", d)
+	}
+
+	SetPolicy("test-removed", &Policy{MaxAttempts: 1})
+	SetPolicy("test-removed", nil)
+	err := baseError.System("TEST_BUSY", "").WithRetryable(true).WithField(FieldQueue, "test-removed")
+	if got := Decide(err); got.Action != Retry {
+		t.Errorf("Decide = %+v", got)
+	}
+}
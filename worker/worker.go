@@ -0,0 +1,107 @@
+// Package worker is the error-handling half of a queue consumer: it runs jobs, recovers
+// their panics, annotates failures with message metadata and decides their disposition.
+package worker
+
+import (
+	"context"
+
+	baseError "github.com/go-tron/base-error"
+)
+
+const CodeJobFailed = "JOB_FAILED"
+
+const (
+	FieldQueue     = "queue"
+	FieldMessageID = "message_id"
+	FieldAttempt   = baseError.FieldAttempt
+)
+
+// HeaderRetryHistory is the message header carrying the baseError.RetryHistory of
+// earlier deliveries.
+const HeaderRetryHistory = "x-retry-history"
+
+type Action int
+
+const (
+	Ack Action = iota
+	Retry
+	DeadLetter
+)
+
+func (a Action) String() string {
+	switch a {
+	case Ack:
+		return "ack"
+	case Retry:
+		return "retry"
+	case DeadLetter:
+		return "dead-letter"
+	}
+	return ""
+}
+
+type Message struct {
+	Queue   string
+	ID      string
+	Attempt int
+	Headers map[string]string
+	Payload []byte
+}
+
+type Job func(ctx context.Context, msg *Message) error
+
+// Handler runs a job and tells the consumer what to do with the message, and for a
+// Retry after how long. err is nil exactly when the action is Ack.
+type Handler func(ctx context.Context, msg *Message) (d Decision, err *baseError.Error)
+
+// Wrap turns job into a Handler. A nil error, typed or not, acks the message. Failures
+// are decided by the policy set for msg.Queue with SetPolicy, or DefaultPolicy;
+// maxAttempts, when positive, overrides its MaxAttempts. Panics are dead-lettered. Every
+// failure is reported through baseError.Report. Failures carry the retry history read
+// from the message headers plus their own attempt, and the updated history is written
+// back to msg.Headers for redelivery. They are annotated on a copy, so jobs may return
+// shared sentinels.
+func Wrap(job Job, maxAttempts int) Handler {
+	return func(ctx context.Context, msg *Message) (d Decision, err *baseError.Error) {
+		defer func() {
+			if r := recover(); r != nil {
+				err = annotate(baseError.FromPanic(r), msg)
+				baseError.Report(err)
+				d = Decision{Action: DeadLetter}
+			}
+		}()
+
+		jobErr := job(ctx, msg)
+		e, ok := jobErr.(*baseError.Error)
+		if jobErr == nil || ok && e == nil {
+			return Decision{Action: Ack}, nil
+		}
+		if ok {
+			e = e.Copy()
+		} else {
+			e = baseError.WrapStack(CodeJobFailed, jobErr, baseError.DefaultStackDepth())
+		}
+		e = annotate(e, msg)
+		baseError.Report(e)
+		policy := PolicyFor(msg.Queue)
+		if maxAttempts > 0 {
+			p := *policy
+			p.MaxAttempts = maxAttempts
+			policy = &p
+		}
+		return policy.Decide(e), e
+	}
+}
+
+func annotate(e *baseError.Error, msg *Message) *baseError.Error {
+	history, _ := baseError.ParseRetryHistory(msg.Headers[HeaderRetryHistory])
+	history = history.Add(e)
+	if msg.Headers == nil {
+		msg.Headers = make(map[string]string)
+	}
+	msg.Headers[HeaderRetryHistory] = history.String()
+	return e.WithField(FieldQueue, msg.Queue).
+		WithField(FieldMessageID, msg.ID).
+		WithAttempt(msg.Attempt).
+		WithRetryHistory(history)
+}
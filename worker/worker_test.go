@@ -0,0 +1,88 @@
+package worker
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	baseError "github.com/go-tron/base-error"
+)
+
+func TestWrap(t *testing.T) {
+	busy := baseError.System("TEST_BUSY", "busy").WithRetryable(true)
+	cases := []struct {
+		job     Job
+		attempt int
+		want    Action
+	}{
+		{func(context.Context, *Message) error { return nil }, 1, Ack},
+		{func(context.Context, *Message) error { var e *baseError.Error; return e }, 1, Ack},
+		{func(context.Context, *Message) error { return busy }, 1, Retry},
+		{func(context.Context, *Message) error { return busy }, 3, DeadLetter},
+		{func(context.Context, *Message) error { return baseError.New("BAD_PAYLOAD", "") }, 1, DeadLetter},
+		{func(context.Context, *Message) error { panic("nil map") }, 1, DeadLetter},
+	}
+	for i, c := range cases {
+		msg := &Message{Queue: "orders", ID: "m-1", Attempt: c.attempt}
+		d, err := Wrap(c.job, 3)(context.Background(), msg)
+		if d.Action != c.want {
+			t.Errorf("%d: action = %s", i, d.Action)
+		}
+		if (err == nil) != (d.Action == Ack) {
+			t.Errorf("%d: err = %v", i, err)
+		}
+		if err != nil && err.Fields()[FieldMessageID] != "m-1" {
+			t.Errorf("%d: fields = %v", i, err.Fields())
+		}
+	}
+}
+
+func TestWrapLeavesSentinel(t *testing.T) {
+	busy := baseError.System("TEST_BUSY", "busy").WithRetryable(true)
+	msg := &Message{Queue: "orders", ID: "m-3", Attempt: 1}
+	_, err := Wrap(func(context.Context, *Message) error { return busy }, 3)(context.Background(), msg)
+	if err == busy || err.Fields()[FieldMessageID] != "m-3" {
+		t.Errorf("err = %v", err)
+	}
+	if len(busy.Fields()) != 0 || busy.Attempt() != 0 {
+		t.Errorf("sentinel annotated: %v", busy.Fields())
+	}
+}
+
+func TestWrapPolicy(t *testing.T) {
+	SetPolicy("test-wrap", &Policy{MaxAttempts: 5, Backoff: time.Second, DeadLetterCodes: []string{"TEST_FRAUD"}})
+	defer SetPolicy("test-wrap", nil)
+	busy := func(context.Context, *Message) error {
+		return baseError.System("TEST_BUSY", "busy").WithRetryable(true)
+	}
+	fraud := func(context.Context, *Message) error {
+		return baseError.System("TEST_FRAUD", "fraud").WithRetryable(true)
+	}
+
+	msg := &Message{Queue: "test-wrap", ID: "m-4", Attempt: 3}
+	if d, _ := Wrap(busy, 0)(context.Background(), msg); d != (Decision{Action: Retry, After: 4 * time.Second}) {
+		t.Errorf("busy = %+v", d)
+	}
+	if d, _ := Wrap(busy, 3)(context.Background(), msg); d.Action != DeadLetter {
+		t.Errorf("busy with maxAttempts 3 = %+v", d)
+	}
+	if d, _ := Wrap(fraud, 0)(context.Background(), msg); d.Action != DeadLetter {
+		t.Errorf("fraud = %+v", d)
+	}
+}
+
+func TestRetryHistoryHeader(t *testing.T) {
+	busy := func(context.Context, *Message) error {
+		return baseError.System("TEST_BUSY", "busy").WithRetryable(true)
+	}
+	msg := &Message{Queue: "orders", ID: "m-2", Attempt: 1}
+	_, err := Wrap(busy, 3)(context.Background(), msg)
+	if len(err.RetryHistory()) != 1 || msg.Headers[HeaderRetryHistory] == "" {
+		t.Fatalf("history = %v, headers = %v", err.RetryHistory(), msg.Headers)
+	}
+	msg.Attempt++
+	_, err = Wrap(busy, 3)(context.Background(), msg)
+	if h := err.RetryHistory(); len(h) != 2 || h[0].Code != "TEST_BUSY" || err.Attempt() != 2 {
+		t.Errorf("history = %v, attempt = %d", h, err.Attempt())
+	}
+}
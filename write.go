@@ -0,0 +1,73 @@
+package baseError
+
+import (
+	"fmt"
+	"io"
+)
+
+type countingWriter struct {
+	w   io.Writer
+	n   int64
+	err error
+}
+
+func (c *countingWriter) Write(p []byte) (int, error) {
+	if c.err != nil {
+		return 0, c.err
+	}
+	n, err := c.w.Write(p)
+	c.n += int64(n)
+	c.err = err
+	return n, err
+}
+
+// WriteTo writes the %+v form of b to w piece by piece, descending into *Error causes
+// without first rendering them into memory.
+func (b *Error) WriteTo(w io.Writer) (int64, error) {
+	if b == nil {
+		n, err := io.WriteString(w, "<nil>")
+		return int64(n), err
+	}
+	cw, ok := w.(*countingWriter)
+	if !ok {
+		cw = &countingWriter{w: w}
+	}
+	start := cw.n
+	io.WriteString(cw, b.Error())
+	b.stack.writeFrames(cw)
+	b.formatDetails(cw)
+	b.formatFields(cw)
+	causes := b.Causes()
+	for i, cause := range causes {
+		if len(causes) == 1 {
+			io.WriteString(cw, "\n---cause---\n")
+		} else {
+			fmt.Fprintf(cw, "\n---cause %d/%d---\n", i+1, len(causes))
+		}
+		if e, ok := cause.(*Error); ok {
+			e.WriteTo(cw)
+		} else {
+			fmt.Fprintf(cw, "%+v", cause)
+		}
+	}
+	return cw.n - start, cw.err
+}
+
+// WriteAll streams the %+v form of every error in errs to w, separated by blank lines.
+func WriteAll(w io.Writer, errs ...error) (int64, error) {
+	cw := &countingWriter{w: w}
+	for i, err := range errs {
+		if i > 0 {
+			io.WriteString(cw, "\n\n")
+		}
+		if e, ok := err.(*Error); ok {
+			e.WriteTo(cw)
+		} else {
+			fmt.Fprintf(cw, "%+v", err)
+		}
+		if cw.err != nil {
+			break
+		}
+	}
+	return cw.n, cw.err
+}
@@ -0,0 +1,27 @@
+package baseError
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"testing"
+)
+
+func TestWriteTo(t *testing.T) {
+	err := WrapStack("OUTER", Wrap("INNER", errors.New("root")), 5).WithDebugDetails("attempt=2")
+
+	var buf bytes.Buffer
+	n, werr := err.WriteTo(&buf)
+	if werr != nil || n != int64(buf.Len()) {
+		t.Fatalf("n=%d len=%d err=%v", n, buf.Len(), werr)
+	}
+	if want := fmt.Sprintf("%+v", err); buf.String() != want {
+		t.Errorf("WriteTo and %%+v differ:\n%s\n---\n%s", buf.String(), want)
+	}
+
+	buf.Reset()
+	WriteAll(&buf, New("A", "a"), errors.New("b"))
+	if buf.String() != "[A] a\n\nb" {
+		t.Errorf("got %q", buf.String())
+	}
+}
@@ -0,0 +1,115 @@
+// Package zapx encodes baseError values as structured zap fields.
+package zapx
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+
+	baseError "github.com/go-tron/base-error"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+// Field returns err as an "error" field; see NamedField.
+func Field(err error) zap.Field {
+	return NamedField("error", err)
+}
+
+// NamedField returns err as a field named key holding its code, msg, system flag, chain,
+// fields and stack when err carries a *Error, and zap.NamedError otherwise.
+func NamedField(key string, err error) zap.Field {
+	var e *baseError.Error
+	if !errors.As(err, &e) || e == nil {
+		return zap.NamedError(key, err)
+	}
+	return zap.Object(key, Object(e))
+}
+
+// Object returns a zapcore.ObjectMarshaler for e.
+func Object(e *baseError.Error) zapcore.ObjectMarshaler {
+	return object{e}
+}
+
+type object struct {
+	e *baseError.Error
+}
+
+func (o object) MarshalLogObject(enc zapcore.ObjectEncoder) error {
+	e := o.e
+	if e == nil {
+		return nil
+	}
+	enc.AddString("code", e.Code)
+	enc.AddString("msg", e.Msg)
+	enc.AddBool("system", e.System)
+	if e.Chain != "" {
+		enc.AddString("chain", e.Chain)
+	}
+	if fields := e.Fields(); len(fields) > 0 {
+		if err := enc.AddObject("fields", fieldsObject(fields)); err != nil {
+			return err
+		}
+	}
+	if frames := e.Stack().Frames(); len(frames) > 0 {
+		return enc.AddArray("stack", zapcore.ArrayMarshalerFunc(func(arr zapcore.ArrayEncoder) error {
+			for _, f := range frames {
+				arr.AppendString(fmt.Sprintf("%s %s:%d", f.Function, f.File, f.Line))
+			}
+			return nil
+		}))
+	}
+	return nil
+}
+
+type fieldsObject map[string]interface{}
+
+func (f fieldsObject) MarshalLogObject(enc zapcore.ObjectEncoder) error {
+	keys := make([]string, 0, len(f))
+	for k := range f {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		if err := enc.AddReflected(k, f[k]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Level returns the zap level of baseError.LogLevel(err).
+func Level(err error) zapcore.Level {
+	switch baseError.LogLevel(err) {
+	case baseError.LevelDebug:
+		return zapcore.DebugLevel
+	case baseError.LevelInfo:
+		return zapcore.InfoLevel
+	case baseError.LevelWarn:
+		return zapcore.WarnLevel
+	}
+	return zapcore.ErrorLevel
+}
+
+// Log logs err as Field(err) at Level(err), after fields. Nothing is logged when
+// baseError.ShouldLog rejects err for the minimum level of logger.
+func Log(logger *zap.Logger, msg string, err error, fields ...zap.Field) {
+	if !baseError.ShouldLog(err, minLevel(zapcore.LevelOf(logger.Core()))) {
+		return
+	}
+	if ce := logger.Check(Level(err), msg); ce != nil {
+		ce.Write(append(fields, Field(err))...)
+	}
+}
+
+func minLevel(l zapcore.Level) baseError.Level {
+	switch {
+	case l <= zapcore.DebugLevel:
+		return baseError.LevelDebug
+	case l == zapcore.InfoLevel:
+		return baseError.LevelInfo
+	case l == zapcore.WarnLevel:
+		return baseError.LevelWarn
+	}
+	return baseError.LevelError
+}
@@ -0,0 +1,57 @@
+package zapx
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	baseError "github.com/go-tron/base-error"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+)
+
+func TestField(t *testing.T) {
+	err := fmt.Errorf("charge: %w", baseError.SystemStack("ZAPX_LEDGER", "ledger down", 4).WithChain("charge").WithField("account", 7))
+	enc := zapcore.NewMapObjectEncoder()
+	Field(err).AddTo(enc)
+
+	got := enc.Fields["error"].(map[string]interface{})
+	if got["code"] != "ZAPX_LEDGER" || got["msg"] != "ledger down" || got["system"] != true || got["chain"] != "charge" {
+		t.Errorf("got %v", got)
+	}
+	if f := got["fields"].(map[string]interface{}); f["account"] != 7 {
+		t.Errorf("fields = %v", f)
+	}
+	if st, _ := got["stack"].([]interface{}); len(st) == 0 {
+		t.Error("stack missing")
+	}
+
+	if f := NamedField("cause", errors.New("raw")); f.Type != zap.NamedError("cause", errors.New("raw")).Type {
+		t.Errorf("foreign field = %v", f)
+	}
+}
+
+func TestLog(t *testing.T) {
+	core, logs := observer.New(zapcore.InfoLevel)
+	logger := zap.New(core)
+	baseError.DefaultRegistry.SetLogLevel("ZAPX_QUIET", baseError.LevelDebug)
+	baseError.DefaultRegistry.SetLogLevel("ZAPX_NOTE", baseError.LevelInfo)
+
+	Log(logger, "quiet", baseError.System("ZAPX_QUIET", ""))
+	baseError.Suppress("ZAPX_MUTED", 0)
+	defer baseError.Unsuppress("ZAPX_MUTED")
+	Log(logger, "muted", baseError.System("ZAPX_MUTED", ""))
+	Log(logger, "note", baseError.System("ZAPX_NOTE", ""), zap.String("req", "r1"))
+
+	entries := logs.All()
+	if len(entries) != 1 || entries[0].Message != "note" || entries[0].Level != zapcore.InfoLevel {
+		t.Fatalf("entries = %v", entries)
+	}
+	if got := entries[0].ContextMap(); got["req"] != "r1" || got["error"].(map[string]interface{})["code"] != "ZAPX_NOTE" {
+		t.Errorf("fields = %v", got)
+	}
+	if Level(baseError.New("ZAPX_PLAIN", "")) != zapcore.WarnLevel {
+		t.Error("business errors default to warn")
+	}
+}